@@ -3,6 +3,7 @@ package application
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/ilius/go-table"
 	c "github.com/ilius/ls-go/common"
@@ -15,7 +16,9 @@ import (
 	"github.com/ilius/ls-go/iface"
 	"github.com/ilius/ls-go/lsargs"
 	"github.com/ilius/ls-go/lscolors"
+	"github.com/ilius/ls-go/lslocale"
 	"github.com/ilius/ls-go/lsplatform"
+	"github.com/ilius/ls-go/lssize"
 	"github.com/ilius/ls-go/lstime"
 	"github.com/ilius/ls-go/terminal"
 )
@@ -42,6 +45,26 @@ type Application struct {
 	QuotingStyle string
 	EnsureASCII  bool
 
+	// quotation marks for --quoting-style=locale
+	LocaleQuoteOpen  string
+	LocaleQuoteClose string
+
+	sortKeys       []sortKey
+	sortExprGetter *ExprGetter
+	grouper        *grouper
+
+	// show "total" line for each directory (number of blocks)
+	showTotal bool
+	// format of size column, and of blocks column and "total" line
+	sizeFormat   *lssize.Format
+	blocksFormat *lssize.Format
+
+	// filters of listed files, like --minsize, --newer and --where
+	filters []fileFilter
+
+	mounts     *mountTable
+	linkGroups *linkGroupTracker
+
 	exitStatus int
 
 	errors []error
@@ -56,6 +79,10 @@ func NewApplication() *Application {
 		Platform:   platform,
 		Terminal:   terminal.NewLocalTerminal(),
 		workDir:    fs.WorkDir(),
+		sizeFormat: lssize.Human(1024),
+		blocksFormat: &lssize.Format{
+			BlockSize: 1024,
+		},
 	}
 }
 
@@ -138,10 +165,19 @@ func (app *Application) extraLongSet(cols map[string]bool, nameParams *FileNameP
 func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 	colors, err := app.Terminal.ColorsEnabled(*args.Color)
 	check(err)
+	if colors {
+		lscolors.Depth, err = app.Terminal.ColorDepth(*args.ColorDepth)
+		check(err)
+	}
 
 	formatter := app.makeFormatter(colors)
 	app.Formatter = formatter
 
+	if !colors {
+		// only icons of color rules are used in plain output
+		disableColorRuleStyles()
+	}
+
 	app.QuestionMark = formatter.Colorize("?", lscolors.Fg(1))
 
 	cols := map[string]bool{}
@@ -150,6 +186,21 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 		*args.Long = true
 		*args.TimeStyle = "full-iso"
 	}
+	{
+		timeZone := *args.TimeZone
+		if *args.UTC {
+			timeZone = "UTC"
+		}
+		loc, err := lstime.LoadLocation(timeZone)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// like setting TZ environment variable, so all time columns,
+		// expression values and json/csv output use this time zone
+		time.Local = loc
+		now := startTime.In(loc)
+		startTime = &now
+	}
 	if *args.NumericUidGid {
 		*args.Long = true
 		getOwnerAndGroup = app.Platform.OwnerAndGroupIDs
@@ -175,6 +226,35 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 	if *args.Shortcut_X {
 		*args.Sort = c.S_EXTENSION
 	}
+	if *args.SortExpr != "" {
+		getter, err := NewExprGetter(false, *args.SortExpr)
+		if err != nil {
+			log.Fatalf("bad --sort-expr: %v", err)
+		}
+		app.sortExprGetter = getter
+	}
+	{
+		sortKeys, err := parseSortKeys(*args.Sort, *args.SortExpr != "")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *args.Shortcut_v {
+			// as a tie-breaker if there is any other sort column
+			sortKeys = append(sortKeys, sortKey{name: c.S_VERSION})
+		}
+		app.sortKeys = sortKeys
+	}
+	{
+		grouper, err := newGrouper(*args.GroupBy, *args.GroupExpr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		app.grouper = grouper
+		// tabular and html show group headers instead
+		if grouper != nil && (*args.Json || *args.JsonArray || *args.Csv) {
+			cols[c.C_GroupKey] = true
+		}
+	}
 
 	{
 		timeCol := *args.Time
@@ -200,7 +280,12 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 	case "":
 		quotingStyle = c.E_shell_escape
 	case c.E_locale:
-		log.Fatalf("unsupported --quoting-style=locale")
+		locale := lslocale.ForMessages()
+		app.LocaleQuoteOpen = locale.QuoteOpen
+		app.LocaleQuoteClose = locale.QuoteClose
+		if *args.ASCII {
+			app.LocaleQuoteOpen, app.LocaleQuoteClose = "'", "'"
+		}
 	case c.E_none,
 		c.E_literal,
 		c.E_shell,
@@ -224,6 +309,28 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 		icons:        *args.Icons,
 		nerdfont:     *args.Nerdfont,
 		fullPath:     len(args.Paths) > 1 || *args.Recursive,
+
+		indicatorStyle:     *args.IndicatorStyle,
+		dataIndicatorStyle: *args.DataIndicatorStyle,
+	}
+	switch {
+	case *args.Shortcut_classify:
+		nameParams.indicatorStyle = c.I_classify
+	case *args.Shortcut_file_type:
+		nameParams.indicatorStyle = c.I_file_type
+	case *args.Shortcut_p:
+		nameParams.indicatorStyle = c.I_slash
+	}
+
+	switch *args.Hyperlink {
+	case "always":
+		nameParams.hyperlink = true
+	case "auto":
+		nameParams.hyperlink = app.Terminal.OutputIsTerminal(os.Stdout)
+	}
+	if nameParams.hyperlink {
+		// like GNU ls, empty host is fine if hostname is unknown
+		nameParams.hyperlinkHost, _ = os.Hostname()
 	}
 
 	if *args.Long {
@@ -238,6 +345,25 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 	if *args.Blocks {
 		cols[c.C_Blocks] = true
 	}
+	if *args.LinkGroup {
+		cols[c.C_LinkGroup] = true
+	}
+	// like `ls -l` and `ls -s`
+	app.showTotal = *args.Long || *args.ExtraLong || cols[c.C_Blocks]
+	{
+		var err error
+		app.sizeFormat, app.blocksFormat, err = makeSizeFormats(formatter)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	{
+		filters, err := makeFilters()
+		if err != nil {
+			log.Fatal(err)
+		}
+		app.filters = filters
+	}
 	if *args.ModeOct {
 		cols[c.C_ModeOct] = true
 	}
@@ -265,18 +391,28 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 	if *args.Atime {
 		cols[c.C_ATime] = true
 	}
+	if *args.Mounts {
+		app.loadMounts()
+		cols[c.C_FsType] = true
+		nameParams.mounts = true
+	}
 	cols[c.C_Name] = true
 
+	if cols[c.C_LinkGroup] || hasSortKey(app.sortKeys, c.S_INODE_GROUP) || *args.Stats {
+		app.linkGroups = newLinkGroupTracker()
+	}
+
 	timeParams := &lstime.TimeParams{}
 	timeStyle := formatter.DefaultTimeStyle()
 	if *args.TimeStyle != "" {
 		timeStyle = *args.TimeStyle
 	}
 	check(timeParams.SetTimeStyle(timeStyle))
+	timeParams.RelativeUnits = *args.RelativeUnits
 
-	exprList := []string{}
-	if *args.Expr != "" {
-		exprList = append(exprList, *args.Expr)
+	exprColumns, err := parseExprColumns(*args.Expr)
+	if err != nil {
+		log.Fatalf("bad --expr: %v", err)
 	}
 
 	tableSpec := makeTableSpec(
@@ -285,7 +421,7 @@ func (app *Application) PostParse(args *lsargs.Arguments) *table.TableSpec {
 		colors,
 		nameParams,
 		timeParams,
-		exprList,
+		exprColumns,
 	)
 	return tableSpec
 }
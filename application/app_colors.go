@@ -18,6 +18,35 @@ func printColorsJson() {
 	fmt.Println(string(b))
 }
 
+// themePreviewSample is the text shown with every color in --theme-preview
+const themePreviewSample = " ls-go 0123 "
+
+// printThemePreview prints every color (with its json path) with a sample
+// text, and description of color and attributes
+func printThemePreview() {
+	colorsEnabled, err := app.Terminal.ColorsEnabled(*args.Color)
+	check(err)
+	if colorsEnabled {
+		col.Depth, err = app.Terminal.ColorDepth(*args.ColorDepth)
+		check(err)
+	}
+	paths := []string{}
+	styles := []*col.Style{}
+	width := 0
+	colors.WalkStyles(func(path string, style *col.Style) {
+		paths = append(paths, path)
+		styles = append(styles, style)
+		width = max(width, len(path))
+	})
+	for i, path := range paths {
+		sample := themePreviewSample
+		if colorsEnabled {
+			sample = styles[i].S() + sample + Reset
+		}
+		fmt.Fprintf(stdout, "%-*s %s %s\n", width, path, sample, styles[i].Describe())
+	}
+}
+
 var colors = col.Colors{
 	File: map[string]col.LightDark{
 		"as":      {Light: col.Fg(196), Dark: col.Fg(124)},
@@ -95,6 +124,15 @@ var colors = col.Colors{
 		NumberColon: col.Fg(10),
 		NumberSlash: col.Fg(249),
 		Word:        col.Fg(4),
+		Age: col.StyleMap{
+			"future": col.Fg(165),
+			"minute": col.Fg(46),
+			"hour":   col.Fg(40),
+			"day":    col.Fg(34),
+			"week":   col.Fg(28),
+			"month":  col.Fg(245),
+			"year":   col.Fg(240),
+		},
 	},
 	Dir: col.DirColors{
 		Name: &col.Style{
@@ -117,8 +155,15 @@ var colors = col.Colors{
 			Slash:      col.FgGray(5),
 			LastFolder: col.Fg(226).SetBold(),
 			Error:      col.Bg(196).SetFg(226),
+			Space:      col.FgGray(12),
+		},
+		GroupHeader: col.GroupHeaderColors{
+			Arrow:   col.Fg(136),
+			Label:   col.Fg(226).SetBold(),
+			Summary: col.FgGray(12),
 		},
 		TableHeader: col.Fg(4),
+		Summary:     col.FgGray(12),
 	},
 	Html: &col.HtmlColors{
 		Default: col.BgGray(1).SetFg(15),
@@ -128,8 +173,15 @@ var colors = col.Colors{
 			Slash:      col.FgGray(5),
 			LastFolder: col.Fg(226).SetBold(),
 			Error:      col.Bg(196).SetFg(226),
+			Space:      col.FgGray(12),
+		},
+		GroupHeader: col.GroupHeaderColors{
+			Arrow:   col.Fg(136),
+			Label:   col.Fg(226).SetBold(),
+			Summary: col.FgGray(12),
 		},
 		TableHeader: col.Fg(4),
+		Summary:     col.FgGray(12),
 	},
 	Link: col.LinkColors{
 		Name: col.Fg(46).SetBold(),
@@ -157,6 +209,12 @@ var colors = col.Colors{
 		Bg:   94,
 		Bold: true,
 	},
+	Mount: col.MountColors{
+		Marker: col.Fg(208).SetBold(),
+		FsType: col.Fg(141),
+		Source: col.FgGray(14),
+	},
+	LinkGroup: col.Fg(178),
 	Stats: col.StatsColors{
 		Text: &col.Style{
 			Bg: col.Gray(2),
@@ -193,6 +251,87 @@ var colors = col.Colors{
 	},
 }
 
+// lightColors overrides colors for light terminal background
+// for File colors, main and accent colors are already swapped by
+// LightDark.Get, so we only override grays
+var lightColors = col.Colors{
+	File: map[string]col.LightDark{
+		"lock":      {Light: col.FgGray(14), Dark: col.FgGray(8)},
+		"log":       {Light: col.FgGray(14), Dark: col.FgGray(8)},
+		"compiled":  {Light: col.FgGray(14), Dark: col.FgGray(8)},
+		col.DEFAULT: {Light: col.FgGray(12), Dark: col.FgGray(2)},
+	},
+	Size: map[string]*col.Style{
+		"B": col.Fg(18),
+		"K": col.Fg(19),
+		"M": col.Fg(25),
+		"G": col.Fg(31),
+		"T": col.Fg(37),
+	},
+	Time: col.TimeColors{
+		Year:        col.Fg(94),
+		Number:      col.Fg(22),
+		NumberColon: col.Fg(28),
+		NumberSlash: col.FgGray(10),
+		Word:        col.Fg(19),
+		Age: col.StyleMap{
+			"future": col.Fg(90),
+			"minute": col.Fg(22),
+			"hour":   col.Fg(28),
+			"day":    col.Fg(34),
+			"week":   col.Fg(70),
+			"month":  col.FgGray(12),
+			"year":   col.FgGray(16),
+		},
+	},
+	Dir: col.DirColors{
+		Name: &col.Style{
+			Fg:   col.Gray(23),
+			Bg:   26,
+			Bold: true,
+		},
+		Ext: col.Fg(55),
+		HiddenName: &col.Style{
+			Fg:   col.Gray(23),
+			Bg:   61,
+			Bold: true,
+		},
+		HiddenExt: col.Fg(55),
+	},
+	Tabular: &col.TabularColors{
+		FolderHeader: col.FolderHeaderColors{
+			Arrow:      col.Fg(130),
+			Main:       col.BgGray(21).SetFg(94),
+			Slash:      col.FgGray(10),
+			LastFolder: col.Fg(130).SetBold(),
+			Space:      col.FgGray(9),
+		},
+		GroupHeader: col.GroupHeaderColors{
+			Arrow:   col.Fg(130),
+			Label:   col.Fg(130).SetBold(),
+			Summary: col.FgGray(9),
+		},
+		TableHeader: col.Fg(19),
+		Summary:     col.FgGray(9),
+	},
+	Link: col.LinkColors{
+		Name: col.Fg(28).SetBold(),
+		NameDir: &col.Style{
+			Bg:   26,
+			Fg:   195,
+			Bold: true,
+		},
+	},
+	Mount: col.MountColors{
+		FsType: col.Fg(92),
+		Source: col.FgGray(8),
+	},
+	LinkGroup: col.Fg(136),
+	Expr: col.ExprColors{
+		Time: col.Fg(136),
+	},
+}
+
 var FileAliases = map[string]string{
 	"s":    "asm",
 	"b":    "bf",
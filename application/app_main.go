@@ -1,7 +1,6 @@
 package application
 
 import (
-	"encoding/json"
 	"io"
 	"os"
 	"runtime/pprof"
@@ -28,12 +27,19 @@ func Run(rawArgs []string) {
 	// parse the arguments and populate the struct
 	args.Parse(rawArgs, VERSION)
 
-	{
-		jsonStr := os.Getenv("LSGO_COLORS")
-		if jsonStr != "" {
-			err := json.Unmarshal([]byte(jsonStr), &colors)
-			check(err)
-		}
+	app = NewApplication()
+
+	applyBackground()
+	applyConfig(args.Config)
+
+	if *args.PrintConfig {
+		args.Config.Print(stdout)
+		os.Exit(0)
+	}
+
+	if *args.ThemePreview {
+		printThemePreview()
+		os.Exit(0)
 	}
 
 	if *args.ColorsJson {
@@ -54,7 +60,6 @@ func Run(rawArgs []string) {
 		defer pprof.StopCPUProfile()
 	}
 
-	app = NewApplication()
 	tableSpec := app.PostParse(args)
 
 	colorsEnable, err := app.Terminal.ColorsEnabled(*args.Color)
@@ -1,9 +1,6 @@
 package application
 
-import (
-	"fmt"
-	"strconv"
-)
+import "fmt"
 
 type BlocksGetter struct{}
 
@@ -20,7 +17,9 @@ func (f *BlocksGetter) ValueString(colName string, item any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
-	return app.FormatValue(colName, info.Blocks())
+	// in units of --block-size
+	unit := app.blocksFormat.Unit()
+	return app.FormatValue(colName, (uint64(info.Blocks())*1024+unit-1)/unit)
 }
 
 func (f *BlocksGetter) Format(item any, _ any) (string, error) {
@@ -29,8 +28,6 @@ func (f *BlocksGetter) Format(item any, _ any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("Format: invalid type %T, must be FileInfo", item)
 	}
-	return strconv.FormatInt(
-		info.Blocks(),
-		10,
-	), nil
+	str, _ := app.blocksFormat.Format(uint64(info.Blocks()) * 1024)
+	return str, nil
 }
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ilius/ls-go/lscolors"
+)
+
+// colorRule is a rule like "size > 1e9 => bold red, icon=X, row=on #303030"
+// given by --color-rule or color_rules of config file
+// if expression is true for a file, its name style, icon and/or row
+// highlight are set
+type colorRule struct {
+	getter *ExprGetter
+
+	// nil or empty if not set by this rule
+	style     *lscolors.Style
+	icon      string
+	highlight *lscolors.Style
+}
+
+// colorRules are evaluated in order, and each matching rule overrides
+// what previous matching rules have set
+var colorRules []*colorRule
+
+// hasHighlightRules is true if any rule sets row highlight
+var hasHighlightRules bool
+
+// ruleMatch is the result of evaluating color rules for a file
+type ruleMatch struct {
+	style     *lscolors.Style
+	icon      string
+	highlight *lscolors.Style
+}
+
+// styleOr returns name style set by color rules, or given style if not set
+func (m *ruleMatch) styleOr(style *lscolors.Style) *lscolors.Style {
+	if m == nil || m.style == nil {
+		return style
+	}
+	return m.style
+}
+
+func (m *ruleMatch) hasIcon() bool {
+	return m != nil && m.icon != ""
+}
+
+// iconOr returns icon set by color rules (followed by a space), or given
+// string if not set
+func (m *ruleMatch) iconOr(str string) string {
+	if !m.hasIcon() {
+		return str
+	}
+	return m.icon + " "
+}
+
+// parseColorRule parses a rule in "EXPR => ACTION, ACTION, ..." format
+// where each action is one of: STYLE (or name=STYLE), icon=ICON, row=STYLE
+func parseColorRule(ruleStr string) (*colorRule, error) {
+	index := strings.LastIndex(ruleStr, "=>")
+	if index < 0 {
+		return nil, fmt.Errorf("missing \"=>\"")
+	}
+	exprStr := strings.TrimSpace(ruleStr[:index])
+	if exprStr == "" {
+		return nil, fmt.Errorf("missing expression before \"=>\"")
+	}
+	rule := &colorRule{}
+	for _, action := range strings.Split(ruleStr[index+2:], ",") {
+		action = strings.TrimSpace(action)
+		key, value, hasKey := strings.Cut(action, "=")
+		if !hasKey {
+			key, value = "name", action
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		var err error
+		switch key {
+		case "name":
+			rule.style, err = lscolors.ParseStyleSpec(value)
+		case "row":
+			rule.highlight, err = lscolors.ParseStyleSpec(value)
+		case "icon":
+			if value == "" {
+				err = fmt.Errorf("empty icon")
+			}
+			rule.icon = value
+		default:
+			err = fmt.Errorf("unknown action %#v, must be name, icon or row", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	getter, err := NewExprGetter(false, exprStr)
+	if err != nil {
+		return nil, err
+	}
+	rule.getter = getter
+	return rule, nil
+}
+
+// setColorRules parses and compiles color rules, must be called after
+// setting expression macros
+func setColorRules(rules []string) error {
+	colorRules = make([]*colorRule, 0, len(rules))
+	hasHighlightRules = false
+	for _, ruleStr := range rules {
+		rule, err := parseColorRule(ruleStr)
+		if err != nil {
+			return fmt.Errorf("color rule %#v: %w", ruleStr, err)
+		}
+		colorRules = append(colorRules, rule)
+		if rule.highlight != nil {
+			hasHighlightRules = true
+		}
+	}
+	return nil
+}
+
+// disableColorRuleStyles removes name styles and row highlights of color
+// rules (when colors are disabled), and rules that have nothing left
+// icons set by rules are still used
+func disableColorRuleStyles() {
+	rules := colorRules[:0]
+	for _, rule := range colorRules {
+		rule.style = nil
+		rule.highlight = nil
+		if rule.icon != "" {
+			rules = append(rules, rule)
+		}
+	}
+	colorRules = rules
+	hasHighlightRules = false
+}
+
+// matchColorRules evaluates color rules for given file, returns nil if
+// no rule matches
+// if highlight is true, only rules that set row highlight are evaluated,
+// otherwise only rules that set name style or icon
+func matchColorRules(info FileInfo, highlight bool) *ruleMatch {
+	var match *ruleMatch
+	for _, rule := range colorRules {
+		if highlight && rule.highlight == nil {
+			continue
+		}
+		if !highlight && rule.style == nil && rule.icon == "" {
+			continue
+		}
+		if !rule.getter.MustValueBool(info) {
+			continue
+		}
+		if match == nil {
+			match = &ruleMatch{}
+		}
+		if highlight {
+			match.highlight = rule.highlight
+			continue
+		}
+		if rule.style != nil {
+			match.style = rule.style
+		}
+		if rule.icon != "" {
+			match.icon = rule.icon
+		}
+	}
+	return match
+}
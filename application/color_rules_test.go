@@ -0,0 +1,69 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+
+	"github.com/ilius/ls-go/lscolors"
+)
+
+func TestParseColorRule(t *testing.T) {
+	is := is.New(t)
+	defer func() {
+		colorRules = nil
+		hasHighlightRules = false
+	}()
+	rule, err := parseColorRule("size > 1e9 => bold red")
+	is.NotErr(err)
+	is.Equal(rule.style, lscolors.Fg(1).SetBold())
+	is.Equal(rule.icon, "")
+	is.Nil(rule.highlight)
+
+	rule, err = parseColorRule(`ext == ".go" => name=#00add8, icon=G, row=on 236`)
+	is.NotErr(err)
+	is.Equal(rule.style.FgHex(), "#00add8")
+	is.Equal(rule.icon, "G")
+	is.Equal(rule.highlight, lscolors.Bg(236))
+
+	rule, err = parseColorRule(`size > 0 => row=underline`)
+	is.NotErr(err)
+	is.Nil(rule.style)
+	is.Equal(rule.highlight, &lscolors.Style{Underline: true})
+
+	_, err = parseColorRule("size > 1e9")
+	is.ErrMsg(err, `missing "=>"`)
+	_, err = parseColorRule(" => red")
+	is.ErrMsg(err, `missing expression before "=>"`)
+	_, err = parseColorRule("size > 0 => bold, size=red")
+	is.ErrMsg(err, `unknown action "size", must be name, icon or row`)
+	_, err = parseColorRule("size > 0 => pink")
+	is.ErrMsg(err, `invalid color "pink"`)
+
+	err = setColorRules([]string{"size > 0 => red", "size > 1 => row=on 236"})
+	is.NotErr(err)
+	is.Equal(len(colorRules), 2)
+	is.True(hasHighlightRules)
+	err = setColorRules([]string{"size > 0 => on"})
+	is.ErrMsg(err, `color rule "size > 0 => on": missing color after "on" in "on"`)
+}
+
+func TestDisableColorRuleStyles(t *testing.T) {
+	is := is.New(t)
+	defer func() {
+		colorRules = nil
+		hasHighlightRules = false
+	}()
+	err := setColorRules([]string{
+		"size > 0 => red",
+		"size > 1 => bold, icon=X, row=on 236",
+		"size > 2 => row=on 236",
+	})
+	is.NotErr(err)
+	disableColorRuleStyles()
+	is.Equal(len(colorRules), 1)
+	is.Equal(colorRules[0].icon, "X")
+	is.Nil(colorRules[0].style)
+	is.Nil(colorRules[0].highlight)
+	is.False(hasHighlightRules)
+}
@@ -1,7 +1,6 @@
 package application
 
 import (
-	"fmt"
 	"io/fs"
 	"log"
 	"reflect"
@@ -10,6 +9,7 @@ import (
 	"github.com/ilius/go-table"
 	c "github.com/ilius/ls-go/common"
 	"github.com/ilius/ls-go/iface"
+	"github.com/ilius/ls-go/lsplatform"
 	"github.com/ilius/ls-go/lstime"
 )
 
@@ -18,6 +18,8 @@ var (
 	t_uint64   = reflect.TypeOf(uint64(0))
 	t_timePtr  = reflect.PtrTo(reflect.TypeOf(time.Time{}))
 	t_FileMode = reflect.TypeOf(fs.FileMode(0))
+
+	t_mountInfoPtr = reflect.TypeOf(&lsplatform.MountInfo{})
 )
 
 func timeColumnFromInput(input string) string {
@@ -41,7 +43,7 @@ func makeTableSpec(
 	colors bool,
 	nameParams *FileNameParams,
 	timeParams *lstime.TimeParams,
-	exprList []string,
+	exprColumns []*exprColumn,
 ) *table.TableSpec {
 	tableSpec := table.NewTableSpec()
 	if cols[c.C_Inode] {
@@ -91,6 +93,16 @@ func makeTableSpec(
 			Getter:     &HardLinksGetter{},
 		})
 	}
+	if cols[c.C_LinkGroup] {
+		tableSpec.AddColumn(&table.Column{
+			Name:       c.C_LinkGroup,
+			Title:      "Link Group",
+			ShortTitle: "LG",
+			Type:       t_string,
+			Alignment:  table.AlignmentLeft,
+			Getter:     NewLinkGroupGetter(colors, formatter.LinkTargetSep()),
+		})
+	}
 	if cols[c.C_Owner] {
 		tableSpec.AddColumn(&table.Column{
 			Name:       c.C_Owner,
@@ -117,7 +129,7 @@ func makeTableSpec(
 			Title:     "Size",
 			Type:      t_uint64,
 			Alignment: table.AlignmentRight,
-			Getter:    NewSizeGetter(colors, formatter.SizeFormat()),
+			Getter:    NewSizeGetter(colors, app.sizeFormat),
 		})
 	}
 	if cols[c.C_MTime] {
@@ -147,6 +159,25 @@ func makeTableSpec(
 			Getter:    NewATimeGetter(colors, timeParams),
 		})
 	}
+	if cols[c.C_FsType] {
+		tableSpec.AddColumn(&table.Column{
+			Name:       c.C_FsType,
+			Title:      "Filesystem",
+			ShortTitle: "FS",
+			Type:       t_mountInfoPtr,
+			Alignment:  table.AlignmentLeft,
+			Getter:     NewFilesystemGetter(colors, formatter.LinkTargetSep()),
+		})
+	}
+	if cols[c.C_GroupKey] {
+		tableSpec.AddColumn(&table.Column{
+			Name:      c.C_GroupKey,
+			Title:     "Group Key",
+			Type:      t_string,
+			Alignment: table.AlignmentLeft,
+			Getter:    &GroupKeyGetter{},
+		})
+	}
 	if cols[c.C_Name] {
 		tableSpec.AddColumn(&table.Column{
 			Name:      c.C_Name,
@@ -156,20 +187,33 @@ func makeTableSpec(
 			Getter:    NewFileNameGetter(colors, nameParams),
 		})
 	}
-	if len(exprList) > 0 {
-		for i, exprStr := range exprList {
-			getter := NewExprGetter(colors, exprStr)
-			_type, err := getter.Type()
-			check(err)
-			alignment, err := getter.Alignment()
-			check(err)
-			tableSpec.AddColumn(&table.Column{
-				Name:      fmt.Sprintf("expr%d", i+1),
-				Title:     exprStr,
-				Type:      _type,
-				Alignment: alignment,
-				Getter:    getter,
-			})
+	for _, exprCol := range exprColumns {
+		getter, err := NewExprGetter(colors, exprCol.exprStr)
+		if err != nil {
+			log.Fatalf("bad --expr: %v", err)
+		}
+		if exprCol.format != "" {
+			getter.SetFormat(exprCol.format)
+		}
+		_type, err := getter.Type()
+		check(err)
+		alignment, err := getter.Alignment()
+		check(err)
+		switch exprCol.alignment {
+		case "left":
+			alignment = table.AlignmentLeft
+		case "right":
+			alignment = table.AlignmentRight
+		}
+		err = addColumnAt(tableSpec, &table.Column{
+			Name:      exprCol.name,
+			Title:     exprCol.title,
+			Type:      _type,
+			Alignment: alignment,
+			Getter:    getter,
+		}, exprCol.before, exprCol.after)
+		if err != nil {
+			log.Fatalf("bad --expr: %v", err)
 		}
 	}
 	return tableSpec
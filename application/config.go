@@ -0,0 +1,120 @@
+package application
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	col "github.com/ilius/ls-go/lscolors"
+	"github.com/ilius/ls-go/lsconfig"
+)
+
+// lsColorsEnv are environment variables used with --ls-colors, in order
+// of priority (lowest first)
+var lsColorsEnv = []struct {
+	name  string
+	apply func(string) error
+}{
+	{"LSCOLORS", colors.ApplyBSDLSColors},
+	{"LS_COLORS", colors.ApplyLSColors},
+	{"EXA_COLORS", colors.ApplyExaColors},
+}
+
+// applyBackground detects terminal background (unless given by --background)
+// and uses colors for light background if needed
+// this is applied before config, so that colors of config file override it
+func applyBackground() {
+	colorsEnabled, err := app.Terminal.ColorsEnabled(*args.Color)
+	check(err)
+	detect := *args.Background == "auto" || *args.Background == "query"
+	if !colorsEnabled && detect {
+		return
+	}
+	// only query terminal for colored output of tabular or html format
+	query := colorsEnabled && !*args.Json && !*args.JsonArray && !*args.Csv &&
+		app.Terminal.OutputIsTerminal(os.Stdout)
+	light, err := app.Terminal.LightBackground(*args.Background, query)
+	check(err)
+	if !light {
+		return
+	}
+	col.LightBackground = true
+	colors.Merge(&lightColors)
+}
+
+// mergeColorsJSON validates colors in JSON format and merges them
+// over current colors
+func mergeColorsJSON(data []byte, source string) {
+	parsed, err := col.ParseColors(data)
+	if err != nil {
+		log.Fatalf("bad colors in %s: %v", source, err)
+	}
+	colors.Merge(parsed)
+}
+
+// applyConfig applies theme (--theme), and colors, icons, macros and color
+// rules of config file (and profile)
+// with --ls-colors, LS_COLORS and similar environment variables override
+// colors of config file
+// LSGO_COLORS environment variable overrides all other colors
+func applyConfig(conf *lsconfig.Effective) {
+	if *args.Theme != "" {
+		err := applyTheme(&colors, *args.Theme)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, confColors := range conf.Colors {
+		mergeColorsJSON(confColors.JSON, confColors.Source)
+	}
+	if *args.LsColors {
+		for _, env := range lsColorsEnv {
+			value := os.Getenv(env.name)
+			if value == "" {
+				continue
+			}
+			err := env.apply(value)
+			if err != nil {
+				// valid entries are still applied
+				log.Printf("warning: bad colors in %s: %v", env.name, err)
+			}
+			valueJSON, _ := json.Marshal(value)
+			conf.Colors = append(conf.Colors, &lsconfig.Colors{
+				JSON:   valueJSON,
+				Source: env.name,
+			})
+		}
+	}
+	jsonStr := os.Getenv("LSGO_COLORS")
+	if jsonStr != "" {
+		mergeColorsJSON([]byte(jsonStr), lsconfig.SourceEnvColors)
+		conf.Colors = append(conf.Colors, &lsconfig.Colors{
+			JSON:   json.RawMessage(jsonStr),
+			Source: lsconfig.SourceEnvColors,
+		})
+	}
+	for key, value := range conf.Icons {
+		icons[strings.ToLower(key)] = value.Value
+	}
+	for key, value := range conf.FolderIcons {
+		folders[key] = value.Value
+	}
+	macros := make(map[string]string, len(conf.Macros))
+	for name, value := range conf.Macros {
+		macros[name] = value.Value
+	}
+	err := setExprMacros(macros)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rules := make([]string, 0, len(conf.ColorRules)+len(*args.ColorRule))
+	for _, rule := range conf.ColorRules {
+		rules = append(rules, rule.Value)
+	}
+	rules = append(rules, *args.ColorRule...)
+	err = setColorRules(rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -5,14 +5,17 @@ import (
 	"io/fs"
 	"reflect"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/expr-lang/expr/vm"
 	"github.com/ilius/go-table"
-	"github.com/ilius/ls-go/common"
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lscolors"
+	"github.com/ilius/ls-go/lslocale"
 	"github.com/ilius/ls-go/lstime"
 )
 
@@ -21,28 +24,86 @@ var (
 	exprFloatPrecision = 6
 )
 
-func NewExprGetter(colors bool, exprStr string) *ExprGetter {
-	return &ExprGetter{
-		prog:   compileExpr(exprStr),
+// NewExprGetter compiles the expression (with macros), returns error if
+// it is invalid, for example if types of values and operators do not match
+func NewExprGetter(colors bool, exprStr string) (*ExprGetter, error) {
+	idents, err := exprIdents(exprStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %#v: %w", exprStr, err)
+	}
+	macros, err := usedExprMacros(idents)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %#v: %w", exprStr, err)
+	}
+	for _, macro := range macros {
+		idents = append(idents, macro.idents...)
+	}
+	prog, err := compileExpr(exprStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %#v: %w", exprStr, err)
+	}
+	getter := &ExprGetter{
+		prog:   prog,
+		fields: usedExprFields(idents),
 		colors: colors,
-		// env:
 	}
+	getter.runners.New = func() any {
+		return &exprRunner{env: newExprEnv()}
+	}
+	return getter, nil
+}
+
+// usedExprFields returns per-file fields used by given identifiers
+func usedExprFields(idents []string) []*exprField {
+	fields := []*exprField{}
+	seen := map[string]bool{}
+	for _, ident := range idents {
+		if seen[ident] {
+			continue
+		}
+		seen[ident] = true
+		if field := exprFieldByName[ident]; field != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
 }
 
-func compileExpr(exprStr string) *vm.Program {
-	prog, err := expr.Compile(
+// compileExpr compiles the expression with exprEnv (for type checking),
+// replacing names of macros with their expressions
+// calls of duration and parse_size with literal arguments (like
+// `duration("24h")`) are evaluated once, here
+func compileExpr(exprStr string) (*vm.Program, error) {
+	return expr.Compile(
 		exprStr,
-		expr.Patch(&patcher{}),
+		expr.Env(*newExprEnv()),
+		expr.ConstExpr("duration"),
+		expr.ConstExpr("parse_size"),
+		expr.Patch(&macroPatcher{}),
 	)
-	check(err)
-	return prog
 }
 
-type patcher struct{}
+// macroPatcher replaces identifiers of macros with their expressions
+type macroPatcher struct{}
 
-func (p *patcher) Enter(_ *ast.Node) {}
-func (p *patcher) Exit(_ *ast.Node)  {}
-func (p *patcher) Visit(_ *ast.Node) {}
+func (p *macroPatcher) Visit(node *ast.Node) {
+	ident, ok := (*node).(*ast.IdentifierNode)
+	if !ok {
+		return
+	}
+	macro := exprMacros[ident.Value]
+	if macro == nil {
+		return
+	}
+	tree, err := parser.Parse(macro.exprStr)
+	if err != nil {
+		// already checked in setExprMacros
+		panic(fmt.Errorf("macro %#v: %w", macro.name, err))
+	}
+	// macros used in macro (there are no cycles, see setExprMacros)
+	ast.Walk(&tree.Node, p)
+	ast.Patch(node, tree.Node)
+}
 
 func parseDuration(s string) time.Duration {
 	dur, err := time.ParseDuration(s)
@@ -58,45 +119,74 @@ ls-go --expr 'mtime().After(now().Add(duration("-1h")))' --sort=time --mtime
 */
 
 type ExprGetter struct {
-	prog   *vm.Program
-	_type  reflect.Type
-	colors bool
-	// env map[string]any
-}
-
-func (f *ExprGetter) evaluateExpr(info FileInfo) (any, error) {
-	value, err := expr.Run(f.prog, map[string]any{
-		"info": info,
-		"now":  *startTime,
-
-		// shortcuts
-		"name":     info.Name(),
-		"size":     info.Size(),
-		"mode":     info.Mode(),
-		"basename": info.Basename(),
-		"ext":      info.Ext(),
-		"dir":      info.Dir(),
+	prog *vm.Program
+	// per-file fields used in expression and its macros
+	fields []*exprField
+	// runners can be used by multiple goroutines (--sort-expr)
+	runners sync.Pool
+	_type   reflect.Type
+	colors  bool
 
-		"parsed_name": func() *common.ParsedName {
-			return app.FileSystem.SplitExt(info.Name())
-		},
+	// format is a fmt format like "%.2f", or strftime format for times
+	// (set for columns of --expr with format option), empty for default
+	format string
+	locale *lslocale.Locale
+}
 
-		// functions for other columns
-		"mtime": info.ModTime,
-		"ctime": func() time.Time { return *info.CTime() },
-		"atime": func() time.Time { return *info.ATime() },
+// SetFormat sets format of values, a fmt format like "%.2f", or a strftime
+// format like "%Y-%m-%d" if value is a time
+func (f *ExprGetter) SetFormat(format string) {
+	f.format = format
+	f.locale = lslocale.ForTime()
+}
 
-		// other functions
-		"past":   func(tm time.Time) bool { return tm.Before(*startTime) },
-		"future": func(tm time.Time) bool { return tm.After(*startTime) },
+// formatValue formats value with f.format, returns false if there is
+// no format
+func (f *ExprGetter) formatValue(value any) (string, bool) {
+	if f.format == "" {
+		return "", false
+	}
+	switch vt := value.(type) {
+	case *time.Time:
+		if vt == nil {
+			return "", true
+		}
+		return f.locale.Strftime(vt.In(time.Local), f.format), true
+	case time.Time:
+		return f.locale.Strftime(vt.In(time.Local), f.format), true
+	}
+	return fmt.Sprintf(f.format, value), true
+}
 
-		"duration": parseDuration,
+// valueStyle returns color style of value based on its type, or nil
+func valueStyle(value any) *lscolors.Style {
+	switch value.(type) {
+	case string:
+		return colors.Expr.String
+	case int, int32, int64, uint, uint32, uint64:
+		return colors.Expr.Integer
+	case float64, float32:
+		return colors.Expr.Float
+	case time.Time, *time.Time:
+		return colors.Expr.Time
+	}
+	return nil
+}
 
-		"split":      strings.Split,
-		"path_split": app.FileSystem.SplitAll,
+// exprRunner has a VM and an environment that are reused for every file
+type exprRunner struct {
+	vm  vm.VM
+	env *exprEnv
+}
 
-		"type": reflect.TypeOf,
-	})
+func (f *ExprGetter) evaluateExpr(info FileInfo) (any, error) {
+	runner := f.runners.Get().(*exprRunner)
+	defer f.runners.Put(runner)
+	env := runner.env
+	for _, field := range f.fields {
+		field.set(env, info)
+	}
+	value, err := runner.vm.Run(f.prog, env)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +200,10 @@ func (f *ExprGetter) evaluateExpr(info FileInfo) (any, error) {
 		return vt(), nil
 	case func() *time.Time:
 		return vt(), nil
+	case func() time.Time:
+		return vt(), nil
+	case func() *c.ParsedName:
+		return vt(), nil
 	case func() int64:
 		return vt(), nil
 	case func() fs.FileMode:
@@ -122,6 +216,16 @@ func (f *ExprGetter) Type() (reflect.Type, error) {
 	if f._type != nil {
 		return f._type, nil
 	}
+	_type := f.prog.Node().Type()
+	// functions without arguments are called in evaluateExpr
+	if _type != nil && _type.Kind() == reflect.Func && _type.NumIn() == 0 && _type.NumOut() == 1 {
+		_type = _type.Out(0)
+	}
+	if _type != nil && _type.Kind() != reflect.Interface {
+		f._type = _type
+		return f._type, nil
+	}
+	// type is not known before running, like `size > 0 ? name : size`
 	value, err := f.evaluateExpr(&FileInfoImp{
 		FileInfo: &FileInfoLow{
 			name:    "",
@@ -241,11 +345,21 @@ func (f *ExprGetter) ValueString(colName string, item any) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if str, ok := f.formatValue(value); ok {
+		return app.FormatValue(colName, str)
+	}
 	return app.FormatValue(colName, fmt.Sprintf("%v", value))
 }
 
 func (f *ExprGetter) Format(_ any, value any) (string, error) {
 	// _: item: not used
+	if str, ok := f.formatValue(value); ok {
+		style := valueStyle(value)
+		if !f.colors || style == nil {
+			return str, nil
+		}
+		return app.Colorize(str, style), nil
+	}
 	if !f.colors {
 		return fmt.Sprintf("%v", value), nil
 	}
@@ -270,9 +384,9 @@ func (f *ExprGetter) Format(_ any, value any) (string, error) {
 		if vt == nil {
 			return "", nil
 		}
-		return app.Colorize(vt.Format(exprTimeFormat), colors.Expr.Time), nil
+		return app.Colorize(vt.In(time.Local).Format(exprTimeFormat), colors.Expr.Time), nil
 	case time.Time:
-		return app.Colorize(vt.Format(exprTimeFormat), colors.Expr.Time), nil
+		return app.Colorize(vt.In(time.Local).Format(exprTimeFormat), colors.Expr.Time), nil
 	case time.Duration:
 		return lstime.FormatDuration(vt), nil
 	}
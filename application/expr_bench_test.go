@@ -0,0 +1,74 @@
+package application
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+const benchExprItemCount = 100_000
+
+// setupExprBenchmark returns benchExprItemCount files (without syscalls)
+func setupExprBenchmark(b *testing.B) []FileInfo {
+	app = NewApplication()
+	now := time.Now()
+	lastStartTime := startTime
+	startTime = &now
+	b.Cleanup(func() {
+		app = nil
+		startTime = lastStartTime
+		exprMacros = map[string]*exprMacro{}
+	})
+	items := make([]FileInfo, benchExprItemCount)
+	for index := range items {
+		basename := fmt.Sprintf("file%d", (index*7919)%benchExprItemCount)
+		ext := []string{".go", ".txt", ".md"}[index%3]
+		items[index] = &FileInfoImp{
+			FileInfo: &FileInfoLow{
+				name:    basename + ext,
+				size:    int64(index * 37 % 100_000),
+				mode:    fs.FileMode(0o644),
+				modTime: now.Add(-time.Duration(index) * time.Minute),
+			},
+			basename: basename,
+			ext:      ext,
+			depth:    1 + index%4,
+		}
+	}
+	return items
+}
+
+// BenchmarkWhere measures the cost of --where per file (ns/file)
+func BenchmarkWhere(b *testing.B) {
+	items := setupExprBenchmark(b)
+	err := setExprMacros(map[string]string{
+		"big": "size > 50 * KiB",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	run := func(name string, exprStr string) {
+		b.Run(name, func(b *testing.B) {
+			getter, err := NewExprGetter(false, exprStr)
+			if err != nil {
+				b.Fatal(err)
+			}
+			count := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, info := range items {
+					if getter.MustValueBool(info) {
+						count++
+					}
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(items)), "ns/file")
+		})
+	}
+	run("Size", "size > 10 * KiB")
+	run("NameAndDepth", "ext == '.go' && depth <= 2 && !is_hidden")
+	run("Glob", "match_glob('*1*.txt', name)")
+	run("Time", "age < duration('24h') || mtime().Year() < 2000")
+	run("Macro", "big && is_file")
+}
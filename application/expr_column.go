@@ -0,0 +1,205 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ilius/go-table"
+	c "github.com/ilius/ls-go/common"
+)
+
+// builtinColumnNames can not be used as names of --expr columns, even if
+// they are not shown, so keys of JSON and CSV are not ambiguous
+var builtinColumnNames = map[string]bool{
+	c.C_Inode:       true,
+	c.C_ModeOct:     true,
+	c.C_Mode:        true,
+	c.C_HardLinks:   true,
+	c.C_Owner:       true,
+	c.C_Group:       true,
+	c.C_Blocks:      true,
+	c.C_Size:        true,
+	c.C_MTime:       true,
+	c.C_CTime:       true,
+	c.C_ATime:       true,
+	c.C_BTime:       true,
+	c.C_Name:        true,
+	c.C_LinkTarget:  true,
+	c.C_FsType:      true,
+	c.C_MountSource: true,
+	c.C_MountPoint:  true,
+	c.C_LinkGroup:   true,
+	c.C_DevInode:    true,
+	c.C_GroupKey:    true,
+}
+
+// exprColumn is a column of --expr, given as
+// "[NAME[:TITLE][[OPTIONS]]=]EXPRESSION", for example
+// "kib:Size (KiB)[format=%.1f,after=size]=size / 1024.0"
+// OPTIONS are comma-separated, each one of:
+// align=left|right, format=FORMAT, before=COLUMN, after=COLUMN
+type exprColumn struct {
+	name    string
+	title   string
+	exprStr string
+
+	// format is a fmt format like "%.2f", or strftime format for times
+	format string
+	// alignment is "", "left" or "right" (empty: based on type of value)
+	alignment string
+
+	// name of column to put this column before or after, or empty for
+	// putting it at the end
+	before string
+	after  string
+}
+
+// identLen returns length of the identifier at start of str
+func identLen(str string) int {
+	for i, c := range str {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return i
+		}
+	}
+	return len(str)
+}
+
+// splitExprColumnHead splits spec into name, title, options and expression
+// ok is false if there is no "NAME...=" head, like "size == 0"
+func splitExprColumnHead(spec string) (name string, title string, options string, exprStr string, ok bool) {
+	n := identLen(spec)
+	if n == 0 {
+		return "", "", "", spec, false
+	}
+	name = spec[:n]
+	rest := spec[n:]
+	if strings.HasPrefix(rest, ":") {
+		end := strings.IndexAny(rest, "[=")
+		if end < 0 {
+			return "", "", "", spec, false
+		}
+		title = rest[1:end]
+		rest = rest[end:]
+	}
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return "", "", "", spec, false
+		}
+		options = rest[1:end]
+		rest = rest[end+1:]
+	}
+	if !strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, "==") {
+		return "", "", "", spec, false
+	}
+	return name, title, options, rest[1:], true
+}
+
+// parseExprColumn parses value of --expr, index is used for the default
+// column name (expr1, expr2, ...)
+func parseExprColumn(spec string, index int) (*exprColumn, error) {
+	name, title, options, exprStr, ok := splitExprColumnHead(spec)
+	if !ok {
+		return &exprColumn{
+			name:    fmt.Sprintf("expr%d", index+1),
+			title:   spec,
+			exprStr: spec,
+		}, nil
+	}
+	if title == "" {
+		title = name
+	}
+	col := &exprColumn{
+		name:    name,
+		title:   title,
+		exprStr: exprStr,
+	}
+	if options == "" {
+		return col, nil
+	}
+	for _, option := range strings.Split(options, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		key = strings.TrimSpace(key)
+		switch key {
+		case "align":
+			switch value {
+			case "left", "right":
+			default:
+				return nil, fmt.Errorf("column %#v: invalid align=%s, must be left or right", name, value)
+			}
+			col.alignment = value
+		case "format":
+			col.format = value
+		case "before":
+			col.before = value
+		case "after":
+			col.after = value
+		default:
+			return nil, fmt.Errorf("column %#v: unknown option %#v, must be align, format, before or after", name, key)
+		}
+	}
+	if col.before != "" && col.after != "" {
+		return nil, fmt.Errorf("column %#v: before and after can not both be set", name)
+	}
+	return col, nil
+}
+
+// parseExprColumns parses values of --expr
+func parseExprColumns(specs []string) ([]*exprColumn, error) {
+	result := make([]*exprColumn, 0, len(specs))
+	names := map[string]bool{}
+	for index, spec := range specs {
+		col, err := parseExprColumn(spec, index)
+		if err != nil {
+			return nil, err
+		}
+		if builtinColumnNames[col.name] {
+			return nil, fmt.Errorf("column name %#v is reserved", col.name)
+		}
+		if names[col.name] {
+			return nil, fmt.Errorf("duplicate column name %#v", col.name)
+		}
+		names[col.name] = true
+		result = append(result, col)
+	}
+	return result, nil
+}
+
+// addColumnAt adds col to tableSpec before or after the column with given
+// name (if not empty), or at the end
+func addColumnAt(tableSpec *table.TableSpec, col *table.Column, before string, after string) error {
+	if tableSpec.HasColumn(col.Name) {
+		return fmt.Errorf("column name %#v is already used", col.Name)
+	}
+	target := before
+	if target == "" {
+		target = after
+	}
+	if target == "" {
+		tableSpec.AddColumn(col)
+		return nil
+	}
+	index := -1
+	for i, other := range tableSpec.Columns {
+		if other.Name == target {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("column %#v: there is no column %#v", col.Name, target)
+	}
+	if after != "" {
+		index++
+	}
+	columns := make([]*table.Column, 0, len(tableSpec.Columns)+1)
+	columns = append(columns, tableSpec.Columns[:index]...)
+	columns = append(columns, col)
+	columns = append(columns, tableSpec.Columns[index:]...)
+	tableSpec.Columns = columns
+	tableSpec.ColumnByName[col.Name] = col
+	return nil
+}
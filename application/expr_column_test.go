@@ -0,0 +1,88 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/go-table"
+	"github.com/ilius/is/v2"
+)
+
+func TestParseExprColumn(t *testing.T) {
+	is := is.New(t)
+	test := func(spec string, expected *exprColumn) {
+		is := is.AddMsg("spec=%#v", spec)
+		col, err := parseExprColumn(spec, 1)
+		is.NotErr(err)
+		is.Equal(col, expected)
+	}
+	test("size / 1024", &exprColumn{name: "expr2", title: "size / 1024", exprStr: "size / 1024"})
+	test("size == 0", &exprColumn{name: "expr2", title: "size == 0", exprStr: "size == 0"})
+	test(`name=="a"`, &exprColumn{name: "expr2", title: `name=="a"`, exprStr: `name=="a"`})
+	test("len(name)", &exprColumn{name: "expr2", title: "len(name)", exprStr: "len(name)"})
+	test("kib=size / 1024", &exprColumn{name: "kib", title: "kib", exprStr: "size / 1024"})
+	test("kib:Size (KiB)=size / 1024", &exprColumn{
+		name:    "kib",
+		title:   "Size (KiB)",
+		exprStr: "size / 1024",
+	})
+	test("kib:Size (KiB)[format=%.1f,align=left,after=size]=size / 1024.0", &exprColumn{
+		name:      "kib",
+		title:     "Size (KiB)",
+		exprStr:   "size / 1024.0",
+		format:    "%.1f",
+		alignment: "left",
+		after:     "size",
+	})
+	test("day[format=%Y-%m-%d,before=name]=mtime", &exprColumn{
+		name:    "day",
+		title:   "day",
+		exprStr: "mtime",
+		format:  "%Y-%m-%d",
+		before:  "name",
+	})
+	for _, spec := range []string{
+		"a[align=center]=1",
+		"a[color=red]=1",
+		"a[before=name,after=size]=1",
+	} {
+		_, err := parseExprColumn(spec, 0)
+		is.AddMsg("spec=%#v", spec).Err(err)
+	}
+}
+
+func TestParseExprColumns(t *testing.T) {
+	is := is.New(t)
+	cols, err := parseExprColumns([]string{"size", "kib=size / 1024", "ext"})
+	is.NotErr(err)
+	is.Equal(len(cols), 3)
+	is.Equal(cols[0].name, "expr1")
+	is.Equal(cols[1].name, "kib")
+	is.Equal(cols[2].name, "expr3")
+
+	_, err = parseExprColumns([]string{"a=1", "a=2"})
+	is.ErrMsg(err, `duplicate column name "a"`)
+	_, err = parseExprColumns([]string{"size=1"})
+	is.ErrMsg(err, `column name "size" is reserved`)
+}
+
+func TestAddColumnAt(t *testing.T) {
+	is := is.New(t)
+	names := func(tableSpec *table.TableSpec) []string {
+		result := []string{}
+		for _, col := range tableSpec.Columns {
+			result = append(result, col.Name)
+		}
+		return result
+	}
+	tableSpec := table.NewTableSpec()
+	tableSpec.AddColumn(&table.Column{Name: "size"})
+	tableSpec.AddColumn(&table.Column{Name: "name"})
+	is.NotErr(addColumnAt(tableSpec, &table.Column{Name: "a"}, "", ""))
+	is.NotErr(addColumnAt(tableSpec, &table.Column{Name: "b"}, "size", ""))
+	is.NotErr(addColumnAt(tableSpec, &table.Column{Name: "c"}, "", "size"))
+	is.NotErr(addColumnAt(tableSpec, &table.Column{Name: "d"}, "", "a"))
+	is.Equal(names(tableSpec), []string{"b", "size", "c", "name", "a", "d"})
+	is.True(tableSpec.HasColumn("c"))
+	is.Err(addColumnAt(tableSpec, &table.Column{Name: "e"}, "mtime", ""))
+	is.Err(addColumnAt(tableSpec, &table.Column{Name: "a"}, "", ""))
+}
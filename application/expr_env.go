@@ -0,0 +1,276 @@
+package application
+
+import (
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lssize"
+)
+
+// exprEnv is the environment of expressions (--expr, --where, --sort-expr,
+// --group-expr, --color-rule and macros), expressions are compiled with it
+// for type checking, see also README.md (Expressions)
+// per-file fields are only set if the expression (or a macro it uses) has
+// their name (see exprFields), so unused (and expensive) fields like owner
+// or link_target cost nothing
+type exprEnv struct {
+	// file info object, with methods like info.Name()
+	Info FileInfo `expr:"info"`
+	// start time of ls-go
+	Now time.Time `expr:"now"`
+
+	Name     string `expr:"name"`
+	Basename string `expr:"basename"` // name without extension
+	Ext      string `expr:"ext"`      // extension, like ".txt"
+	Dir      string `expr:"dir"`      // directory (as given or listed)
+	Path     string `expr:"path"`     // absolute path
+	// 0 for path arguments, 1 for their contents, 2 for contents of their
+	// sub-directories (with -R), etc
+	Depth int `expr:"depth"`
+
+	Size   int64       `expr:"size"`
+	Blocks int64       `expr:"blocks"` // number of 1024-byte blocks
+	Mode   fs.FileMode `expr:"mode"`
+	// permission bits like chmod (like 0o4755), see bitand()
+	Perm int `expr:"perm"`
+
+	Owner      string `expr:"owner"` // owner name (or id with -n)
+	Group      string `expr:"group"` // group name (or id with -n)
+	Inode      uint64 `expr:"inode"`
+	NLink      uint64 `expr:"nlink"`       // number of hard links
+	LinkTarget string `expr:"link_target"` // empty if not a link
+
+	IsDir    bool `expr:"is_dir"`
+	IsFile   bool `expr:"is_file"` // regular file
+	IsLink   bool `expr:"is_link"`
+	IsExec   bool `expr:"is_exec"` // regular file with any executable bit
+	IsHidden bool `expr:"is_hidden"`
+
+	// time since modification (now - mtime)
+	Age time.Duration `expr:"age"`
+
+	ParsedName func() *c.ParsedName `expr:"parsed_name"`
+	MTime      func() time.Time     `expr:"mtime"`
+	CTime      func() time.Time     `expr:"ctime"` // zero if not available
+	ATime      func() time.Time     `expr:"atime"` // zero if not available
+	BTime      func() time.Time     `expr:"btime"` // zero if not available
+
+	// functions and constants, same for all files (set by newExprEnv)
+
+	Past     func(tm time.Time) bool        `expr:"past"`
+	Future   func(tm time.Time) bool        `expr:"future"`
+	Duration func(str string) time.Duration `expr:"duration"`
+
+	Split     func(str string, sep string) []string `expr:"split"`
+	PathSplit func(path string) []string            `expr:"path_split"`
+	Type      func(value any) reflect.Type          `expr:"type"`
+
+	MatchGlob  func(pattern string, str string) (bool, error) `expr:"match_glob"`
+	MatchRegex func(pattern string, str string) (bool, error) `expr:"match_regex"`
+	HumanSize  func(size int64) string                        `expr:"human_size"`
+	ParseSize  func(str string) (int64, error)                `expr:"parse_size"`
+
+	// size constants, like `size > 10 * MiB`
+	KB  int64 `expr:"KB"`
+	MB  int64 `expr:"MB"`
+	GB  int64 `expr:"GB"`
+	TB  int64 `expr:"TB"`
+	KiB int64 `expr:"KiB"`
+	MiB int64 `expr:"MiB"`
+	GiB int64 `expr:"GiB"`
+	TiB int64 `expr:"TiB"`
+}
+
+// exprField sets a per-file field of exprEnv
+type exprField struct {
+	name string
+	set  func(env *exprEnv, info FileInfo)
+}
+
+var exprFields = []*exprField{
+	{"info", func(env *exprEnv, info FileInfo) {
+		env.Info = info
+	}},
+	{"now", func(env *exprEnv, _ FileInfo) {
+		env.Now = *startTime
+	}},
+	{"name", func(env *exprEnv, info FileInfo) {
+		env.Name = info.Name()
+	}},
+	{"basename", func(env *exprEnv, info FileInfo) {
+		env.Basename = info.Basename()
+	}},
+	{"ext", func(env *exprEnv, info FileInfo) {
+		env.Ext = info.Ext()
+	}},
+	{"dir", func(env *exprEnv, info FileInfo) {
+		env.Dir = info.Dir()
+	}},
+	{"path", func(env *exprEnv, info FileInfo) {
+		env.Path = info.PathAbs()
+	}},
+	{"depth", func(env *exprEnv, info FileInfo) {
+		env.Depth = info.Depth()
+	}},
+	{"size", func(env *exprEnv, info FileInfo) {
+		env.Size = info.Size()
+	}},
+	{"blocks", func(env *exprEnv, info FileInfo) {
+		env.Blocks = info.Blocks()
+	}},
+	{"mode", func(env *exprEnv, info FileInfo) {
+		env.Mode = info.Mode()
+	}},
+	{"perm", func(env *exprEnv, info FileInfo) {
+		env.Perm = int(unixPerm(info.Mode()))
+	}},
+	{"owner", func(env *exprEnv, info FileInfo) {
+		env.Owner = info.Owner()
+	}},
+	{"group", func(env *exprEnv, info FileInfo) {
+		env.Group = info.Group()
+	}},
+	{"inode", func(env *exprEnv, info FileInfo) {
+		env.Inode, _ = info.Inode()
+	}},
+	{"nlink", func(env *exprEnv, info FileInfo) {
+		env.NLink, _ = info.NumberOfHardLinks()
+	}},
+	{"link_target", func(env *exprEnv, info FileInfo) {
+		env.LinkTarget = ""
+		if info.Mode()&fs.ModeSymlink == 0 {
+			return
+		}
+		target, err := app.FileSystem.ReadLink(info.PathAbs())
+		if err == nil {
+			env.LinkTarget = target
+		}
+	}},
+	{"is_dir", func(env *exprEnv, info FileInfo) {
+		env.IsDir = info.IsDir()
+	}},
+	{"is_file", func(env *exprEnv, info FileInfo) {
+		env.IsFile = info.Mode().IsRegular()
+	}},
+	{"is_link", func(env *exprEnv, info FileInfo) {
+		env.IsLink = info.Mode()&fs.ModeSymlink != 0
+	}},
+	{"is_exec", func(env *exprEnv, info FileInfo) {
+		mode := info.Mode()
+		env.IsExec = mode.IsRegular() && mode&0o111 != 0
+	}},
+	{"is_hidden", func(env *exprEnv, info FileInfo) {
+		name := info.Name()
+		env.IsHidden = name != "" && name[0] == '.'
+	}},
+	{"age", func(env *exprEnv, info FileInfo) {
+		env.Age = startTime.Sub(info.ModTime())
+	}},
+	{"parsed_name", func(env *exprEnv, info FileInfo) {
+		env.ParsedName = func() *c.ParsedName {
+			return app.FileSystem.SplitExt(info.Name())
+		}
+	}},
+	{"mtime", func(env *exprEnv, info FileInfo) {
+		env.MTime = info.ModTime
+	}},
+	{"ctime", func(env *exprEnv, info FileInfo) {
+		env.CTime = func() time.Time { return timeOrZero(info.CTime()) }
+	}},
+	{"atime", func(env *exprEnv, info FileInfo) {
+		env.ATime = func() time.Time { return timeOrZero(info.ATime()) }
+	}},
+	{"btime", func(env *exprEnv, info FileInfo) {
+		env.BTime = func() time.Time { return timeOrZero(info.Time(c.C_BTime)) }
+	}},
+}
+
+// timeOrZero returns zero time if tm is not available (like ctime and
+// atime of --read-json input without them)
+func timeOrZero(tm *time.Time) time.Time {
+	if tm == nil {
+		return time.Time{}
+	}
+	return *tm
+}
+
+var exprFieldByName = func() map[string]*exprField {
+	m := make(map[string]*exprField, len(exprFields))
+	for _, field := range exprFields {
+		m[field.name] = field
+	}
+	return m
+}()
+
+var (
+	exprRegexpCache      = map[string]*regexp.Regexp{}
+	exprRegexpCacheMutex sync.Mutex
+)
+
+// exprRegexp compiles (or returns cached) regexp, can be called by
+// multiple goroutines (--sort-expr)
+func exprRegexp(pattern string) (*regexp.Regexp, error) {
+	exprRegexpCacheMutex.Lock()
+	defer exprRegexpCacheMutex.Unlock()
+	re := exprRegexpCache[pattern]
+	if re != nil {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	exprRegexpCache[pattern] = re
+	return re, nil
+}
+
+var exprHumanSize = lssize.Human(1024)
+
+// newExprEnv returns an environment with functions and constants set
+func newExprEnv() *exprEnv {
+	return &exprEnv{
+		Past:     func(tm time.Time) bool { return tm.Before(*startTime) },
+		Future:   func(tm time.Time) bool { return tm.After(*startTime) },
+		Duration: parseDuration,
+
+		Split:     strings.Split,
+		PathSplit: func(path string) []string { return app.FileSystem.SplitAll(path) },
+		Type:      reflect.TypeOf,
+
+		MatchGlob: filepath.Match,
+		MatchRegex: func(pattern string, str string) (bool, error) {
+			re, err := exprRegexp(pattern)
+			if err != nil {
+				return false, err
+			}
+			return re.MatchString(str), nil
+		},
+		HumanSize: func(size int64) string {
+			if size < 0 {
+				return strconv.FormatInt(size, 10)
+			}
+			str, _ := exprHumanSize.Format(uint64(size))
+			return str
+		},
+		ParseSize: func(str string) (int64, error) {
+			size, err := lssize.ParseSize(str)
+			return int64(size), err
+		},
+
+		KB:  1000,
+		MB:  1000 * 1000,
+		GB:  1000 * 1000 * 1000,
+		TB:  1000 * 1000 * 1000 * 1000,
+		KiB: 1 << 10,
+		MiB: 1 << 20,
+		GiB: 1 << 30,
+		TiB: 1 << 40,
+	}
+}
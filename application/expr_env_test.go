@@ -0,0 +1,88 @@
+package application
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestUsedExprFields(t *testing.T) {
+	is := is.New(t)
+	fields := usedExprFields([]string{"size", "MiB", "owner", "size", "x", "match_glob"})
+	names := []string{}
+	for _, field := range fields {
+		names = append(names, field.name)
+	}
+	is.Equal(names, []string{"size", "owner"})
+}
+
+func TestExprEnv(t *testing.T) {
+	is := is.New(t)
+	lastStartTime := startTime
+	defer func() {
+		startTime = lastStartTime
+	}()
+	now := time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)
+	startTime = &now
+	info := &FileInfoImp{
+		FileInfo: &FileInfoLow{
+			name:    "run.sh",
+			size:    3 << 20,
+			mode:    fs.ModeSetuid | 0o755,
+			modTime: now.Add(-2 * time.Hour),
+		},
+		basename: "run",
+		ext:      ".sh",
+		depth:    2,
+	}
+	test := func(exprStr string, expected any) {
+		is := is.AddMsg("expr=%#v", exprStr)
+		getter, err := NewExprGetter(false, exprStr)
+		is.NotErr(err)
+		value, err := getter.evaluateExpr(info)
+		is.NotErr(err)
+		is.Equal(value, expected)
+	}
+	test("size > 2 * MiB && size < 4 * MB", true)
+	test("human_size(size)", "3.0M")
+	test("size == parse_size('3M')", true)
+	test("is_exec && is_file && !is_dir && !is_link && !is_hidden", true)
+	test("perm", 0o4755)
+	test("bitand(perm, 0o4000) != 0", true)
+	test("depth", 2)
+	test("basename + ext", "run.sh")
+	test("match_glob('*.sh', name)", true)
+	test("match_regex('^r.n', name)", true)
+	test("age < duration('3h')", true)
+}
+
+// noTimesFileInfo is like a file of --read-json input without ctime and atime
+type noTimesFileInfo struct {
+	*FileInfoImp
+}
+
+func (*noTimesFileInfo) CTime() *time.Time {
+	return nil
+}
+
+func (*noTimesFileInfo) ATime() *time.Time {
+	return nil
+}
+
+func TestExprEnvNoTimes(t *testing.T) {
+	is := is.New(t)
+	info := &noTimesFileInfo{&FileInfoImp{
+		FileInfo: &FileInfoLow{name: "a"},
+		basename: "a",
+	}}
+	for _, exprStr := range []string{"ctime()", "atime()"} {
+		is := is.AddMsg("expr=%#v", exprStr)
+		getter, err := NewExprGetter(false, exprStr)
+		is.NotErr(err)
+		value, err := getter.evaluateExpr(info)
+		is.NotErr(err)
+		is.Equal(value, time.Time{})
+	}
+}
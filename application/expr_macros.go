@@ -0,0 +1,118 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+)
+
+// exprMacro is a named expression from config file, that can be used
+// by name (like a variable) in other expressions
+type exprMacro struct {
+	name    string
+	exprStr string
+	// names of identifiers used in the expression
+	idents []string
+}
+
+var exprMacros = map[string]*exprMacro{}
+
+type identCollector struct {
+	idents []string
+}
+
+func (c *identCollector) Visit(node *ast.Node) {
+	ident, ok := (*node).(*ast.IdentifierNode)
+	if !ok {
+		return
+	}
+	c.idents = append(c.idents, ident.Value)
+}
+
+// exprIdents returns names of identifiers used in given expression
+func exprIdents(exprStr string) ([]string, error) {
+	tree, err := parser.Parse(exprStr)
+	if err != nil {
+		return nil, err
+	}
+	collector := &identCollector{}
+	ast.Walk(&tree.Node, collector)
+	return collector.idents, nil
+}
+
+// setExprMacros parses and checks given macros (name -> expression)
+// macros are compiled as part of expressions that use them
+func setExprMacros(macros map[string]string) error {
+	names := make([]string, 0, len(macros))
+	for name := range macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exprStr := macros[name]
+		idents, err := exprIdents(exprStr)
+		if err != nil {
+			return fmt.Errorf("macro %#v: %w", name, err)
+		}
+		exprMacros[name] = &exprMacro{
+			name:    name,
+			exprStr: exprStr,
+			idents:  idents,
+		}
+	}
+	// check for cycles
+	for _, name := range names {
+		_, err := usedExprMacros([]string{name})
+		if err != nil {
+			return fmt.Errorf("macro %#v: %w", name, err)
+		}
+	}
+	// check types, after all macros are set
+	for _, name := range names {
+		_, err := compileExpr(macros[name])
+		if err != nil {
+			return fmt.Errorf("macro %#v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// usedExprMacros returns macros used by given identifiers (directly or
+// indirectly), in the order they need to be evaluated
+func usedExprMacros(idents []string) ([]*exprMacro, error) {
+	result := []*exprMacro{}
+	// state: 1 = visiting, 2 = done
+	state := map[string]int{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		macro := exprMacros[name]
+		if macro == nil {
+			return nil
+		}
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("macro %#v is used recursively", name)
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, ident := range macro.idents {
+			err := visit(ident)
+			if err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		result = append(result, macro)
+		return nil
+	}
+	for _, ident := range idents {
+		err := visit(ident)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
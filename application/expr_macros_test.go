@@ -0,0 +1,42 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestUsedExprMacros(t *testing.T) {
+	is := is.New(t)
+	defer func() {
+		exprMacros = map[string]*exprMacro{}
+	}()
+	err := setExprMacros(map[string]string{
+		"big":     "size > 1000000",
+		"text":    "ext == '.txt'",
+		"bigtext": "big && text",
+		"unused":  "name",
+	})
+	is.NotErr(err)
+	names := func(exprStr string) []string {
+		idents, err := exprIdents(exprStr)
+		is.NotErr(err)
+		macros, err := usedExprMacros(idents)
+		is.NotErr(err)
+		result := []string{}
+		for _, macro := range macros {
+			result = append(result, macro.name)
+		}
+		return result
+	}
+	is.Equal(names("size"), []string{})
+	is.Equal(names("bigtext || text"), []string{"big", "text", "bigtext"})
+	is.Equal(names("info.Name() == 'big'"), []string{})
+
+	exprMacros = map[string]*exprMacro{}
+	err = setExprMacros(map[string]string{
+		"a": "b",
+		"b": "!a",
+	})
+	is.ErrMsg(err, `macro "a": macro "a" is used recursively`)
+}
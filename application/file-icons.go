@@ -8,6 +8,9 @@ func getIconForFile(name, ext string) string {
 	// default icon for all files. try to find a better one though...
 	icon := icons["file"]
 
+	// ext may start with a dot (as in FileInfo.Ext)
+	ext = strings.TrimPrefix(ext, ".")
+
 	// resolve aliased extensions
 	extKey := strings.ToLower(ext)
 	alias, hasAlias := aliases[extKey]
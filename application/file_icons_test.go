@@ -0,0 +1,20 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestGetIconForFile(t *testing.T) {
+	is := is.New(t)
+	test := func(name string, ext string, expected string) {
+		is.AddMsg("name=%#v, ext=%#v", name, ext).Equal(getIconForFile(name, ext), expected)
+	}
+	// extension with or without leading dot
+	test("main", ".go", icons["go"])
+	test("main", "go", icons["go"])
+	test("config", ".YAML", icons["yml"])
+	test("Makefile", "", icons["makefile"])
+	test("data", ".unknown-ext", icons["file"])
+}
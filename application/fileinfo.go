@@ -25,6 +25,9 @@ type FileInfoImp struct {
 	dir    string
 	curDir string
 	isAbs  bool
+
+	// 0 for path arguments, 1 for contents of directory arguments, etc
+	depth int
 }
 
 func (info *FileInfoImp) Basename() string {
@@ -47,6 +50,10 @@ func (info *FileInfoImp) CurDir() string {
 	return info.curDir
 }
 
+func (info *FileInfoImp) Depth() int {
+	return info.depth
+}
+
 func (info *FileInfoImp) IsAbs() bool {
 	return info.isAbs
 }
@@ -97,6 +104,8 @@ func (info *FileInfoImp) Time(colName string) *time.Time {
 		return app.Platform.FileCTime(info)
 	case c.C_ATime:
 		return app.Platform.FileATime(info)
+	case c.C_BTime:
+		return app.Platform.FileBTime(info)
 	}
 	panic(fmt.Errorf("invalid colName=%#v", colName))
 }
@@ -139,6 +148,11 @@ func (info *FileInfoImp) Inode() (uint64, error) {
 	return app.Platform.FileInode(info)
 }
 
+// DeviceID returns ID of the device containing the file
+func (info *FileInfoImp) DeviceID() (uint64, error) {
+	return app.Platform.FileDevice(info)
+}
+
 func (info *FileInfoImp) NumberOfHardLinks() (uint64, error) {
 	return app.Platform.NumberOfHardLinks(info)
 }
@@ -25,6 +25,23 @@ type FileNameParams struct {
 	linkRel      bool
 	icons        bool
 	nerdfont     bool
+	mounts       bool
+
+	// indicator styles for display and for csv/json (ValueString)
+	indicatorStyle     string
+	dataIndicatorStyle string
+
+	// for --hyperlink, hyperlinkHost is host name used in file:// URLs
+	hyperlink     bool
+	hyperlinkHost string
+}
+
+// hyperlinkName makes displayName a link to file (if --hyperlink is enabled)
+func (p *FileNameParams) hyperlinkName(info FileInfo, displayName string) string {
+	if !p.hyperlink {
+		return displayName
+	}
+	return app.Hyperlink(displayName, fileURL(p.hyperlinkHost, info.PathAbs()))
 }
 
 // check for executable permissions
@@ -48,10 +65,25 @@ func (f *FileNameGetter) Value(item any) (any, error) {
 	if info.Mode()&os.ModeSymlink != 0 {
 		link = getLinkInfo(info, parentDirAbs, f.linkRel)
 	}
-	displayName := f.nameString(info, link)
+	var match *ruleMatch
+	if len(colorRules) > 0 {
+		match = matchColorRules(info, false)
+	}
+	displayName := f.hyperlinkName(info, appendIndicator(
+		f.nameString(info, link, match),
+		f.nameIndicator(info),
+	))
+
+	if f.mounts && isMountPoint(info) {
+		// same separator as FileNameGetterPlain, outside of name color
+		displayName += " " + app.Colorize(mountMarker, colors.Mount.Marker)
+	}
 
 	if f.showLinks && info.Mode()&os.ModeSymlink != 0 {
-		displayName += app.Colorize("► ", colors.Link.Arrow) + f.linkTargetString(link)
+		displayName += app.Colorize("► ", colors.Link.Arrow) + appendIndicator(
+			f.linkTargetString(link),
+			f.linkTargetIndicator(link),
+		)
 	}
 
 	return displayName, nil
@@ -67,9 +99,7 @@ func (f *FileNameGetter) ValueString(colName string, item any) (string, error) {
 		// info.Dir for relative path, info.DirAbs() for absoulte path
 		filename = app.FileSystem.Join(info.DirAbs(), filename)
 	}
-	if info.IsDir() {
-		filename += "/"
-	}
+	filename += fileIndicator(info.Mode(), f.dataIndicatorStyle)
 	str, err := app.FormatValue(colName, filename)
 	if err != nil {
 		return "", err
@@ -97,17 +127,22 @@ func (f *FileNameGetter) Format(_ any, value any) (string, error) {
 	return value.(string), nil
 }
 
-func (f *FileNameGetter) nameString(info FileInfo, link *LinkInfo) string {
+// nameString returns colored name of file, match is the result of color
+// rules (nil if no rule matches)
+func (f *FileNameGetter) nameString(info FileInfo, link *LinkInfo, match *ruleMatch) string {
 	mode := info.Mode()
 	if mode&os.ModeDir != 0 {
-		return f.dirString(info.Name(), info.PathDisplay())
+		return f.dirString(info.Name(), info.PathDisplay(), mode, match)
 	}
 	if mode&os.ModeSymlink != 0 {
-		return f.linkString(info, link)
+		return f.linkString(info, link, match)
 	}
 	if mode&os.ModeDevice != 0 {
 		name := info.PathDisplay()
-		color := colors.Device
+		color := match.styleOr(deviceColor(mode))
+		if match.hasIcon() {
+			return app.Colorize(match.icon+" "+name+" ", color)
+		}
 		if f.nerdfont {
 			return app.Colorize(otherIcons["device"]+" "+name+" ", color)
 		}
@@ -117,18 +152,25 @@ func (f *FileNameGetter) nameString(info FileInfo, link *LinkInfo) string {
 		return app.Colorize(" "+name+" ", color)
 	}
 	if mode&os.ModeNamedPipe != 0 {
-		return app.Colorize(" "+info.PathDisplay()+" ", colors.Pipe)
+		return app.Colorize(match.iconOr(" ")+info.PathDisplay()+" ", match.styleOr(colors.Pipe))
 	}
 	if mode&os.ModeSocket != 0 {
-		return app.Colorize(" "+info.PathDisplay()+" ", colors.Socket)
+		return app.Colorize(match.iconOr(" ")+info.PathDisplay()+" ", match.styleOr(colors.Socket))
 	}
-	return f.fileString(info)
+	return f.fileString(info, match)
 }
 
-func (f *FileNameGetter) linkString(info FileInfo, link *LinkInfo) string {
+func (f *FileNameGetter) linkString(info FileInfo, link *LinkInfo, match *ruleMatch) string {
 	name := info.PathDisplay()
 	if !link.broken && link.isDir {
 		color := colors.Link.NameDir
+		if colors.Link.Target {
+			color = linkTargetColor(link)
+		}
+		color = match.styleOr(color)
+		if match.hasIcon() {
+			return app.Colorize(match.icon+" "+name+" ", color) + " "
+		}
 		if f.nerdfont {
 			var linkIcon string
 			if link.broken {
@@ -143,6 +185,15 @@ func (f *FileNameGetter) linkString(info FileInfo, link *LinkInfo) string {
 		return app.Colorize(" "+name+" ", color) + " "
 	}
 	color := colors.Link.Name
+	if link.broken && colors.Link.Orphan != nil {
+		color = colors.Link.Orphan
+	} else if colors.Link.Target && !link.broken {
+		color = linkTargetColor(link)
+	}
+	color = match.styleOr(color)
+	if match.hasIcon() {
+		return app.Colorize(match.icon+" "+name+" ", color)
+	}
 	if f.nerdfont {
 		if link.broken {
 			return app.Colorize(otherIcons["brokenLink"]+" "+name+" ", color)
@@ -155,12 +206,37 @@ func (f *FileNameGetter) linkString(info FileInfo, link *LinkInfo) string {
 	return app.Colorize(name+" ", color)
 }
 
+// linkTargetColor returns color of target of (not broken) link, used for
+// name of link with "ln=target" in LS_COLORS
+func linkTargetColor(link *LinkInfo) *lscolors.Style {
+	info := link.info
+	if info == nil {
+		if link.isDir {
+			return colors.Dir.Name
+		}
+		return colors.Link.Name
+	}
+	mode := info.Mode()
+	switch {
+	case mode&fs.ModeDir != 0:
+		return dirColor(info.Name(), mode)
+	case mode&fs.ModeDevice != 0:
+		return deviceColor(mode)
+	case mode&fs.ModeNamedPipe != 0:
+		return colors.Pipe
+	case mode&fs.ModeSocket != 0:
+		return colors.Socket
+	}
+	mainColor, _ := fileColors(info)
+	return mainColor
+}
+
 func (f *FileNameGetter) linkTargetString(link *LinkInfo) string {
 	if link.broken {
 		return app.Colorize(link.targetDisplay, colors.Link.Broken)
 	}
 	if link.isDir {
-		return f.dirString(link.target, link.targetDisplay)
+		return f.dirString(link.target, link.targetDisplay, 0, nil)
 	}
 	if link.info == nil {
 		return link.targetDisplay
@@ -168,7 +244,7 @@ func (f *FileNameGetter) linkTargetString(link *LinkInfo) string {
 	info := link.info
 	mode := info.Mode()
 	if mode&os.ModeDir != 0 {
-		return f.dirString(info.Name(), info.PathDisplay())
+		return f.dirString(info.Name(), info.PathDisplay(), mode, nil)
 	}
 	name := info.PathDisplay()
 	if mode&os.ModeSymlink != 0 {
@@ -184,7 +260,7 @@ func (f *FileNameGetter) linkTargetString(link *LinkInfo) string {
 		return app.Colorize(name+" ", color)
 	}
 	if mode&os.ModeDevice != 0 {
-		color := colors.Device
+		color := deviceColor(mode)
 		if f.nerdfont {
 			return app.Colorize(otherIcons["device"]+" "+name+" ", color)
 		}
@@ -199,7 +275,7 @@ func (f *FileNameGetter) linkTargetString(link *LinkInfo) string {
 	if mode&os.ModeSocket != 0 {
 		return app.Colorize(" "+name+" ", colors.Socket)
 	}
-	return f.fileString(info)
+	return f.fileString(info, nil)
 }
 
 func (f *FileNameGetter) fileIcon(info FileInfo, mainColor *lscolors.Style) string {
@@ -216,30 +292,22 @@ func (f *FileNameGetter) fileIcon(info FileInfo, mainColor *lscolors.Style) stri
 	return ""
 }
 
-func (f *FileNameGetter) fileString(info FileInfo) string {
+func (f *FileNameGetter) fileString(info FileInfo, match *ruleMatch) string {
 	basename := info.Basename()
 	ext := info.Ext()
 	suffix := info.Suffix()
-	key := ""
-	if ext != "" {
-		key = strings.ToLower(ext)[1:]
-	}
-	// figure out which color to choose
-	color := colors.File[lscolors.DEFAULT]
-	alias, hasAlias := FileAliases[key]
-	if hasAlias {
-		key = alias
-	}
-	betterColor, hasBetterColor := colors.File[key]
-	if hasBetterColor {
-		color = betterColor
+	mainColor, accentColor := fileColors(info)
+	if match != nil && match.style != nil {
+		mainColor, accentColor = match.style, match.style
 	}
-	mainColor, accentColor := color.Get()
 
 	// in some cases files have icons if front
 	// if nerd font enabled, then it'll be a file-specific icon, or if its an executable script, a little shell icon
 	// if the regular --icons flag is used instead, then it will show a ">_" only if the file is executable
 	icon := f.fileIcon(info, mainColor)
+	if match.hasIcon() {
+		icon = app.Colorize(match.icon+" ", mainColor)
+	}
 	quotedBasename := quoteFileName(basename)
 	colorize := app.Colorize
 	if quotedBasename != basename {
@@ -253,13 +321,77 @@ func (f *FileNameGetter) fileString(info FileInfo) string {
 	}, "")
 }
 
-func (f *FileNameGetter) dirString(name string, pathDisplay string) string {
-	color := colors.Dir.Name
+// fileColors returns colors of base name and extension of a regular file
+func fileColors(info FileInfo) (*lscolors.Style, *lscolors.Style) {
+	ext := info.Ext()
+	key := ""
+	if ext != "" {
+		key = strings.ToLower(ext)[1:]
+	}
+	// figure out which color to choose
+	color := colors.File[lscolors.DEFAULT]
+	betterColor, hasBetterColor := colors.File[key]
+	if !hasBetterColor {
+		alias, hasAlias := FileAliases[key]
+		if hasAlias {
+			betterColor, hasBetterColor = colors.File[alias]
+		}
+	}
+	if hasBetterColor {
+		color = betterColor
+	}
+	mainColor, accentColor := color.Get()
+	if style := specialFileColor(info); style != nil {
+		mainColor, accentColor = style, style
+	}
+	return mainColor, accentColor
+}
+
+// specialFileColor returns color of file based on its mode (setuid, setgid,
+// executable) or name suffix, or nil to color it by extension
+func specialFileColor(info FileInfo) *lscolors.Style {
+	mode := info.Mode()
+	switch {
+	case mode&fs.ModeSetuid != 0 && colors.Setuid != nil:
+		return colors.Setuid
+	case mode&fs.ModeSetgid != 0 && colors.Setgid != nil:
+		return colors.Setgid
+	case isExecutableFile(info) && colors.Exec != nil:
+		return colors.Exec
+	}
+	return colors.GetSuffixStyle(info.Name())
+}
+
+func deviceColor(mode fs.FileMode) *lscolors.Style {
+	if mode&fs.ModeCharDevice != 0 && colors.CharDevice != nil {
+		return colors.CharDevice
+	}
+	return colors.Device
+}
+
+func dirColor(name string, mode fs.FileMode) *lscolors.Style {
+	sticky := mode&fs.ModeSticky != 0
+	otherWritable := mode&0o002 != 0
+	switch {
+	case sticky && otherWritable && colors.Dir.StickyOtherWritable != nil:
+		return colors.Dir.StickyOtherWritable
+	case otherWritable && colors.Dir.OtherWritable != nil:
+		return colors.Dir.OtherWritable
+	case sticky && colors.Dir.Sticky != nil:
+		return colors.Dir.Sticky
+	}
 	if strings.HasPrefix(name, ".") {
-		color = colors.Dir.HiddenName
+		return colors.Dir.HiddenName
 	}
+	return colors.Dir.Name
+}
+
+func (f *FileNameGetter) dirString(name string, pathDisplay string, mode fs.FileMode, match *ruleMatch) string {
+	color := match.styleOr(dirColor(name, mode))
 	icon := " "
-	if f.icons {
+	if match.hasIcon() {
+		icon = match.icon + " "
+	} else if f.icons {
 		icon = "📂 "
 	} else if f.nerdfont {
 		icon = getIconForFolder(name) + " "
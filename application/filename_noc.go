@@ -22,10 +22,24 @@ func (f *FileNameGetterPlain) Value(item any) (any, error) {
 	if info.Mode()&os.ModeSymlink != 0 {
 		link = getLinkInfo(info, parentDirAbs, f.linkRel)
 	}
-	displayName := f.nameString(info, link)
+	var match *ruleMatch
+	if len(colorRules) > 0 {
+		match = matchColorRules(info, false)
+	}
+	displayName := f.hyperlinkName(info, appendIndicator(
+		f.nameString(info, link, match),
+		f.nameIndicator(info),
+	))
+
+	if f.mounts && isMountPoint(info) {
+		displayName += " " + mountMarker
+	}
 
 	if f.showLinks && info.Mode()&os.ModeSymlink != 0 {
-		displayName += " ► " + f.linkTargetString(link)
+		displayName += " ► " + appendIndicator(
+			f.linkTargetString(link),
+			f.linkTargetIndicator(link),
+		)
 	}
 
 	return displayName, nil
@@ -41,9 +55,7 @@ func (f *FileNameGetterPlain) ValueString(colName string, item any) (string, err
 		// info.Dir for relative path, info.DirAbs() for absoulte path
 		filename = app.FileSystem.Join(info.DirAbs(), filename)
 	}
-	if info.IsDir() {
-		filename += "/"
-	}
+	filename += fileIndicator(info.Mode(), f.dataIndicatorStyle)
 	str, err := app.FormatValue(colName, filename)
 	if err != nil {
 		return "", err
@@ -70,7 +82,12 @@ func (f *FileNameGetterPlain) Format(_ any, value any) (string, error) {
 	return value.(string), nil
 }
 
-func (f *FileNameGetterPlain) nameString(info FileInfo, link *LinkInfo) string {
+// nameString returns name of file with icon, match is the result of color
+// rules (nil if no rule matches), only its icon is used
+func (f *FileNameGetterPlain) nameString(info FileInfo, link *LinkInfo, match *ruleMatch) string {
+	if match.hasIcon() {
+		return match.icon + " " + info.PathDisplay()
+	}
 	mode := info.Mode()
 	if mode&os.ModeDir != 0 {
 		return f.dirString(info.Name(), info.PathDisplay())
@@ -0,0 +1,154 @@
+package application
+
+// this is a port of filevercmp from gnulib, used by `ls -v` and `sort -V`
+// see https://www.gnu.org/software/coreutils/manual/html_node/Version-sort-ordering.html
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isAlpha(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// filePrefixLen returns the length of s without its suffix, which is
+// the longest match of this regexp: (\.[A-Za-z~][A-Za-z0-9~]*)*$
+func filePrefixLen(s string) int {
+	n := len(s)
+	prefixLen := 0
+	for i := 0; i < n; {
+		i++
+		prefixLen = i
+		for i+1 < n && s[i] == '.' && (isAlpha(s[i+1]) || s[i+1] == '~') {
+			for i += 2; i < n && (isAlpha(s[i]) || isDigit(s[i]) || s[i] == '~'); i++ {
+			}
+		}
+	}
+	return prefixLen
+}
+
+// verOrder returns the sort weight of byte at given position
+// end of string sorts before everything except '~'
+func verOrder(s string, pos int) int {
+	if pos == len(s) {
+		return -1
+	}
+	c := s[pos]
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -2
+	}
+	return int(c) + 256
+}
+
+// verrevcmp is the version comparison algorithm of Debian (dpkg)
+func verrevcmp(s1 string, s2 string) int {
+	pos1, pos2 := 0, 0
+	len1, len2 := len(s1), len(s2)
+	for pos1 < len1 || pos2 < len2 {
+		firstDiff := 0
+		for (pos1 < len1 && !isDigit(s1[pos1])) || (pos2 < len2 && !isDigit(s2[pos2])) {
+			c1 := verOrder(s1, pos1)
+			c2 := verOrder(s2, pos2)
+			if c1 != c2 {
+				return c1 - c2
+			}
+			pos1++
+			pos2++
+		}
+		for pos1 < len1 && s1[pos1] == '0' {
+			pos1++
+		}
+		for pos2 < len2 && s2[pos2] == '0' {
+			pos2++
+		}
+		for pos1 < len1 && pos2 < len2 && isDigit(s1[pos1]) && isDigit(s2[pos2]) {
+			if firstDiff == 0 {
+				firstDiff = int(s1[pos1]) - int(s2[pos2])
+			}
+			pos1++
+			pos2++
+		}
+		if pos1 < len1 && isDigit(s1[pos1]) {
+			return 1
+		}
+		if pos2 < len2 && isDigit(s2[pos2]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// filevercmp compares file names containing version numbers
+// returns negative if a < b, zero if a == b and positive if a > b
+func filevercmp(a string, b string) int {
+	// special case for empty versions
+	if a == "" {
+		if b == "" {
+			return 0
+		}
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+
+	// special cases for leading ".": "." sorts first, then "..",
+	// then other names with leading ".", then other names
+	if a[0] == '.' {
+		if b[0] != '.' {
+			return -1
+		}
+		if a == "." {
+			if b == "." {
+				return 0
+			}
+			return -1
+		}
+		if b == "." {
+			return 1
+		}
+		if a == ".." {
+			if b == ".." {
+				return 0
+			}
+			return -1
+		}
+		if b == ".." {
+			return 1
+		}
+	} else if b[0] == '.' {
+		return 1
+	}
+
+	// cut file suffixes
+	aPrefixLen := filePrefixLen(a)
+	bPrefixLen := filePrefixLen(b)
+
+	result := verrevcmp(a[:aPrefixLen], b[:bPrefixLen])
+	if result != 0 {
+		return result
+	}
+	// if both suffixes are empty, a second pass would return the same thing
+	if aPrefixLen == len(a) && bPrefixLen == len(b) {
+		return 0
+	}
+	return verrevcmp(a, b)
+}
+
+// versionLess is used by `ls -v`, names that are equal in version order
+// are compared byte by byte
+func versionLess(a string, b string) bool {
+	diff := filevercmp(a, b)
+	if diff != 0 {
+		return diff < 0
+	}
+	return a < b
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+// sorted list of names, taken from gnulib's tests/test-filevercmp.c
+var filevercmpExamples = []string{
+	"",
+	".",
+	"..",
+	".0",
+	".9",
+	".A",
+	".Z",
+	".a~",
+	".a",
+	".b~",
+	".b",
+	".z",
+	".zz~",
+	".zz",
+	".zz.~1~",
+	".zz.0",
+	"0",
+	"9",
+	"A",
+	"Z",
+	"a~",
+	"a",
+	"a.b~",
+	"a.b",
+	"a.bc~",
+	"a.bc",
+	"a+",
+	"a.",
+	"a..a",
+	"a.+",
+	"b~",
+	"b",
+	"gcc-c++-10.fc9.tar.gz",
+	"gcc-c++-10.fc9.tar.gz.~1~",
+	"gcc-c++-10.fc9.tar.gz.~2~",
+	"gcc-c++-10.8.12-0.7rc2.fc9.tar.bz2",
+	"gcc-c++-10.8.12-0.7rc2.fc9.tar.bz2.~1~",
+	"glibc-2-0.1.beta1.fc10.rpm",
+	"glibc-common-5-0.2.beta2.fc9.ebuild",
+	"glibc-common-5-0.2b.deb",
+	"glibc-common-11b.ebuild",
+	"glibc-common-11-0.6rc2.ebuild",
+	"libstdc++-0.5.8.11-0.7rc2.fc10.tar.gz",
+	"libstdc++-4a.fc8.tar.gz",
+	"libstdc++-4.10.4.20040204svn.rpm",
+	"libstdc++-devel-3.fc8.ebuild",
+	"libstdc++-devel-3a.fc9.tar.gz",
+	"libstdc++-devel-8.fc8.deb",
+	"libstdc++-devel-8.6.2-0.4b.fc8",
+	"nss_ldap-1-0.2b.fc9.tar.bz2",
+	"nss_ldap-1-0.6rc2.fc8.tar.gz",
+	"nss_ldap-1.0-0.1a.tar.gz",
+	"nss_ldap-10beta1.fc8.tar.gz",
+	"nss_ldap-10.11.8.6.20040204cvs.fc10.ebuild",
+	"z",
+	"zz~",
+	"zz",
+	"zz.~1~",
+	"zz.0",
+	"zz.0.txt",
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+func TestFilevercmp(t *testing.T) {
+	is := is.New(t)
+	for i, a := range filevercmpExamples {
+		for j, b := range filevercmpExamples {
+			is.AddMsg("a=%#v, b=%#v", a, b).Equal(
+				sign(filevercmp(a, b)),
+				sign(i-j),
+			)
+		}
+	}
+
+	test := func(a string, b string, result int) {
+		is.AddMsg("a=%#v, b=%#v", a, b).Equal(sign(filevercmp(a, b)), result)
+	}
+	test("file2", "file10", -1)
+	test("file02", "file2", 0) // ls -v breaks this tie with strcmp
+	test("a1b2", "a1b10", -1)
+	test("ls-go-1.2.0.tar.gz", "ls-go-1.10.0.tar.gz", -1)
+	test("ls-go-1.2.0-rc1.tar.gz", "ls-go-1.2.0.tar.gz", 1)
+	test("ls-go-1.2.0~rc1.tar.gz", "ls-go-1.2.0.tar.gz", -1)
+	test("img9.png", "img10.png", -1)
+	test("abc", "abd", -1)
+}
+
+func TestVersionSort(t *testing.T) {
+	is := is.New(t)
+	names := []string{
+		"file10.txt",
+		"file1.txt",
+		"file2.txt",
+		"File3.txt",
+		"file02.txt",
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return versionLess(names[i], names[j])
+	})
+	is.Equal(names, []string{
+		"File3.txt",
+		"file1.txt",
+		"file02.txt",
+		"file2.txt",
+		"file10.txt",
+	})
+}
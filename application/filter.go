@@ -0,0 +1,314 @@
+package application
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lsplatform"
+	"github.com/ilius/ls-go/lssize"
+	"github.com/ilius/ls-go/lstime"
+)
+
+// fileFilter returns true if the file should be listed
+type fileFilter func(info FileInfo) bool
+
+// matchFilters returns true if info passes all filters
+func matchFilters(filters []fileFilter, info FileInfo) bool {
+	for _, filter := range filters {
+		if !filter(info) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTimeFromInput returns time name for --filter-time, which unlike
+// --time also supports birth time
+func filterTimeFromInput(input string) string {
+	switch input {
+	case "btime", "birth", "creation", "created":
+		return c.C_BTime
+	}
+	return timeColumnFromInput(input)
+}
+
+func sizeFilter(flag string, value string, match func(size int64, limit int64) bool) (fileFilter, error) {
+	limit, err := lssize.ParseSize(value)
+	if err != nil {
+		return nil, fmt.Errorf("bad %s: %w", flag, err)
+	}
+	return func(info FileInfo) bool {
+		return match(info.Size(), int64(limit))
+	}, nil
+}
+
+// timeFilter returns a filter on time of given name (like mtime), files
+// without that time (like birth time on some file systems) are skipped
+func timeFilter(timeName string, match func(tm time.Time) bool) fileFilter {
+	return func(info FileInfo) bool {
+		tm := info.Time(timeName)
+		if tm == nil {
+			return false
+		}
+		return match(*tm)
+	}
+}
+
+// refFileTime returns time of given name of the file at path,
+// for --newer-than and --older-than
+func refFileTime(flag string, path string, timeName string) (time.Time, error) {
+	stat, err := app.FileSystem.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bad %s: %w", flag, err)
+	}
+	info := &FileInfoImp{
+		FileInfo: stat,
+		dir:      app.FileSystem.Dir(path),
+	}
+	tm := info.Time(timeName)
+	if tm == nil {
+		return time.Time{}, fmt.Errorf("bad %s: %s of %#v is not available", flag, timeName, path)
+	}
+	return *tm, nil
+}
+
+// makeFilters returns filters of --minsize, --maxsize, time filters
+// (--newer, --older, --newer-than, --older-than, --after, --before),
+// --where, --type, --empty, --broken-links, --owner-is, --group-is,
+// --perm-is and --has-mode
+func makeFilters() ([]fileFilter, error) {
+	filters := []fileFilter{}
+	if *args.Minsize != "" {
+		filter, err := sizeFilter("--minsize", *args.Minsize, func(size int64, limit int64) bool {
+			return size >= limit
+		})
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if *args.Maxsize != "" {
+		filter, err := sizeFilter("--maxsize", *args.Maxsize, func(size int64, limit int64) bool {
+			return size <= limit
+		})
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	timeName := filterTimeFromInput(*args.FilterTime)
+	if *args.Newer != "" {
+		age, err := lstime.ParseAge(*args.Newer)
+		if err != nil {
+			return nil, fmt.Errorf("bad --newer: %w", err)
+		}
+		cutoff := startTime.Add(-age)
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return tm.After(cutoff)
+		}))
+	}
+	if *args.Older != "" {
+		age, err := lstime.ParseAge(*args.Older)
+		if err != nil {
+			return nil, fmt.Errorf("bad --older: %w", err)
+		}
+		cutoff := startTime.Add(-age)
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return tm.Before(cutoff)
+		}))
+	}
+	if *args.NewerThan != "" {
+		ref, err := refFileTime("--newer-than", *args.NewerThan, timeName)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return tm.After(ref)
+		}))
+	}
+	if *args.OlderThan != "" {
+		ref, err := refFileTime("--older-than", *args.OlderThan, timeName)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return tm.Before(ref)
+		}))
+	}
+	if *args.After != "" {
+		date, err := lstime.ParseDate(*args.After)
+		if err != nil {
+			return nil, fmt.Errorf("bad --after: %w", err)
+		}
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return !tm.Before(date)
+		}))
+	}
+	if *args.Before != "" {
+		date, err := lstime.ParseDate(*args.Before)
+		if err != nil {
+			return nil, fmt.Errorf("bad --before: %w", err)
+		}
+		filters = append(filters, timeFilter(timeName, func(tm time.Time) bool {
+			return tm.Before(date)
+		}))
+	}
+
+	if *args.Where != "" {
+		getter, err := NewExprGetter(false, *args.Where)
+		if err != nil {
+			return nil, fmt.Errorf("bad --where: %w", err)
+		}
+		filters = append(filters, func(info FileInfo) bool {
+			return getter.MustValueBool(info)
+		})
+	}
+	if *args.Type != "" {
+		filter, err := typeFilter(*args.Type)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if *args.Empty {
+		filters = append(filters, isEmpty)
+	}
+	if *args.BrokenLinks {
+		filters = append(filters, isBrokenLink)
+	}
+	if *args.OwnerIs != "" {
+		filters = append(filters, idFilter(*args.OwnerIs, FileInfo.Owner, func(og *lsplatform.OwnerGroup) string {
+			return og.Owner
+		}))
+	}
+	if *args.GroupIs != "" {
+		filters = append(filters, idFilter(*args.GroupIs, FileInfo.Group, func(og *lsplatform.OwnerGroup) string {
+			return og.Group
+		}))
+	}
+	if *args.PermIs != "" {
+		filter, err := permFilter("--perm-is", *args.PermIs)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if *args.HasMode != "" {
+		// same as --perm-is=-MODE, but only octal
+		if _, err := strconv.ParseUint(*args.HasMode, 8, 16); err != nil {
+			return nil, fmt.Errorf("bad --has-mode: invalid octal mode %#v", *args.HasMode)
+		}
+		filter, err := permFilter("--has-mode", "-"+*args.HasMode)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func permFilter(flag string, value string) (fileFilter, error) {
+	match, err := parsePerm(value)
+	if err != nil {
+		return nil, fmt.Errorf("bad %s: %w", flag, err)
+	}
+	return func(info FileInfo) bool {
+		return match(unixPerm(info.Mode()))
+	}, nil
+}
+
+// fileTypeLetters are letters of --type, like find and fd
+// x is for executable regular files
+var fileTypeLetters = map[string]func(mode fs.FileMode) bool{
+	"f": fs.FileMode.IsRegular,
+	"d": fs.FileMode.IsDir,
+	"l": func(mode fs.FileMode) bool {
+		return mode&fs.ModeSymlink != 0
+	},
+	"p": func(mode fs.FileMode) bool {
+		return mode&fs.ModeNamedPipe != 0
+	},
+	"s": func(mode fs.FileMode) bool {
+		return mode&fs.ModeSocket != 0
+	},
+	"b": func(mode fs.FileMode) bool {
+		return mode&fs.ModeDevice != 0 && mode&fs.ModeCharDevice == 0
+	},
+	"c": func(mode fs.FileMode) bool {
+		return mode&fs.ModeCharDevice != 0
+	},
+	"x": func(mode fs.FileMode) bool {
+		return mode.IsRegular() && mode&0o111 != 0
+	},
+}
+
+// typeFilter returns filter of --type, like "f" or "f,d,l"
+// (files of any of given types)
+func typeFilter(value string) (fileFilter, error) {
+	funcs := []func(mode fs.FileMode) bool{}
+	for _, letter := range strings.Split(value, ",") {
+		fn := fileTypeLetters[strings.TrimSpace(letter)]
+		if fn == nil {
+			return nil, fmt.Errorf("bad --type: invalid type %#v, must be one of f, d, l, p, s, b, c, x", letter)
+		}
+		funcs = append(funcs, fn)
+	}
+	return func(info FileInfo) bool {
+		mode := info.Mode()
+		for _, fn := range funcs {
+			if fn(mode) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// isEmpty returns true for empty regular files and empty directories
+// (like find -empty)
+func isEmpty(info FileInfo) bool {
+	if info.Mode().IsRegular() {
+		return info.Size() == 0
+	}
+	if !info.IsDir() {
+		return false
+	}
+	entries, err := app.FileSystem.ReadDir(info.PathAbs())
+	return err == nil && len(entries) == 0
+}
+
+// isBrokenLink returns true for symbolic links with missing target
+func isBrokenLink(info FileInfo) bool {
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return false
+	}
+	_, err := app.FileSystem.Stat(info.PathAbs())
+	return os.IsNotExist(err)
+}
+
+// idFilter returns filter of --owner-is or --group-is, matching name
+// (returned by getName) or numeric id (returned by getID)
+func idFilter(value string, getName func(FileInfo) string, getID func(*lsplatform.OwnerGroup) string) fileFilter {
+	_, err := strconv.ParseUint(value, 10, 32)
+	numeric := err == nil
+	return func(info FileInfo) bool {
+		if getName(info) == value {
+			return true
+		}
+		if !numeric {
+			return false
+		}
+		// not available with --read-json
+		if _, ok := info.(*FileInfoImp); !ok {
+			return false
+		}
+		og, err := app.Platform.OwnerAndGroupIDs(info)
+		return err == nil && getID(og) == value
+	}
+}
@@ -0,0 +1,271 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ilius/go-table"
+	c "github.com/ilius/ls-go/common"
+)
+
+// grouper splits listed items into groups (sections) by a key (--group-by)
+type grouper struct {
+	// key returns group key of an item
+	key func(info FileInfo) string
+
+	// order is the order of known keys, other keys come after them
+	// in alphabetical order
+	order []string
+
+	// emptyLabel is shown in group header for empty key
+	emptyLabel string
+}
+
+func newGrouper(name string, exprStr string) (*grouper, error) {
+	if exprStr != "" && name == "" {
+		name = c.G_EXPR
+	}
+	switch name {
+	case "":
+		return nil, nil
+	case c.G_EXT:
+		return &grouper{
+			key:        FileInfo.Ext,
+			emptyLabel: "no extension",
+		}, nil
+	case c.G_KIND:
+		return &grouper{
+			key:   fileKind,
+			order: []string{kindDotfile, kindNoExt},
+		}, nil
+	case c.G_OWNER:
+		return &grouper{
+			key:        FileInfo.Owner,
+			emptyLabel: "unknown owner",
+		}, nil
+	case c.G_TYPE:
+		return &grouper{
+			key:   fileTypeName,
+			order: fileTypeNames,
+		}, nil
+	case c.G_AGE:
+		return &grouper{
+			key: func(info FileInfo) string {
+				return ageGroup(info.Time(app.PrimaryTimeColName))
+			},
+			order:      ageGroups,
+			emptyLabel: "unknown time",
+		}, nil
+	case c.G_EXPR:
+		if exprStr == "" {
+			return nil, fmt.Errorf("--group-by=%s requires --group-expr", c.G_EXPR)
+		}
+		getter, err := NewExprGetter(false, exprStr)
+		if err != nil {
+			return nil, fmt.Errorf("bad --group-expr: %w", err)
+		}
+		return &grouper{
+			key: func(info FileInfo) string {
+				value, err := getter.evaluateExpr(info)
+				if err != nil {
+					app.AddError(&c.FileError{
+						Path: info.PathDisplay(),
+						Msg:  "--group-expr: " + err.Error(),
+					})
+					return ""
+				}
+				return fmt.Sprintf("%v", value)
+			},
+			emptyLabel: "empty",
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid --group-by=%#v", name)
+}
+
+// Label returns the text shown in group header
+func (g *grouper) Label(key string) string {
+	if key == "" && g.emptyLabel != "" {
+		return g.emptyLabel
+	}
+	return key
+}
+
+func (g *grouper) compareKeys(a string, b string) int {
+	ai := slices.Index(g.order, a)
+	bi := slices.Index(g.order, b)
+	switch {
+	case ai >= 0 && bi >= 0:
+		return ai - bi
+	case ai >= 0:
+		return -1
+	case bi >= 0:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// printGroups prints sorted items in groups (--group-by)
+// each group with a header that shows number of items and total size
+func (app *Application) printGroups(tableObj *table.Table, items []*DisplayItem) {
+	for _, group := range app.grouper.Split(items, *args.Reverse) {
+		app.GroupHeader(
+			stdout,
+			app.grouper.Label(group.key),
+			len(group.items),
+			group.size,
+		)
+		check(app.PrintItems(stdout, tableObj, DisplayItemList(group.items)))
+	}
+}
+
+// itemGroup is a group of listed items with the same key
+type itemGroup struct {
+	key   string
+	items []*DisplayItem
+	size  uint64
+}
+
+// Split partitions sorted items into groups (by their GroupKey), keeping
+// the order of items in each group, groups are sorted by key (reversed if
+// reverse is true)
+func (g *grouper) Split(items []*DisplayItem, reverse bool) []*itemGroup {
+	groups := []*itemGroup{}
+	byKey := map[string]*itemGroup{}
+	for _, item := range items {
+		key := item.GroupKey
+		group := byKey[key]
+		if group == nil {
+			group = &itemGroup{key: key}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.items = append(group.items, item)
+		if !item.IsDir() {
+			group.size += uint64(item.Size())
+		}
+	}
+	slices.SortFunc(groups, func(a *itemGroup, b *itemGroup) int {
+		if reverse {
+			return g.compareKeys(b.key, a.key)
+		}
+		return g.compareKeys(a.key, b.key)
+	})
+	return groups
+}
+
+const (
+	kindDotfile = "dotfiles"
+	kindNoExt   = "no extension"
+)
+
+// fileKind is similar to compareKind: dotfiles, files without
+// extension, and then one kind for each extension
+func fileKind(info FileInfo) string {
+	if info.Basename() == "" {
+		return kindDotfile
+	}
+	if info.Ext() == "" {
+		return kindNoExt
+	}
+	return strings.ToLower(info.Ext())
+}
+
+var fileTypeNames = []string{
+	"directory",
+	"symlink",
+	"file",
+	"executable",
+	"pipe",
+	"socket",
+	"block device",
+	"char device",
+}
+
+func fileTypeName(info FileInfo) string {
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		return "directory"
+	case mode&os.ModeSymlink != 0:
+		return "symlink"
+	case mode&os.ModeNamedPipe != 0:
+		return "pipe"
+	case mode&os.ModeSocket != 0:
+		return "socket"
+	case mode&os.ModeCharDevice != 0:
+		return "char device"
+	case mode&os.ModeDevice != 0:
+		return "block device"
+	case mode&0o111 != 0:
+		return "executable"
+	}
+	return "file"
+}
+
+var ageGroups = []string{
+	"future",
+	"today",
+	"this week",
+	"this month",
+	"older",
+}
+
+// ageGroup returns the age bucket of given time, based on calendar
+// (local time) and relative to start time of program
+// weeks start on Monday
+func ageGroup(tm *time.Time) string {
+	if tm == nil {
+		return ""
+	}
+	now := *startTime
+	if tm.After(now) {
+		return "future"
+	}
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	if !tm.Before(today) {
+		return "today"
+	}
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	if !tm.Before(today.AddDate(0, 0, -daysSinceMonday)) {
+		return "this week"
+	}
+	if !tm.Before(time.Date(year, month, 1, 0, 0, 0, 0, now.Location())) {
+		return "this month"
+	}
+	return "older"
+}
+
+// groupedFileInfo is passed to FormatItem (instead of FileInfo) with
+// --group-by, so group_key column does not compute the key again
+type groupedFileInfo struct {
+	FileInfo
+	groupKey string
+}
+
+// GroupKeyGetter is the getter for group_key column, which is only added
+// for json and csv output, because tabular output shows group headers
+type GroupKeyGetter struct{}
+
+func (*GroupKeyGetter) Value(item any) (any, error) {
+	info, ok := item.(*groupedFileInfo)
+	if !ok {
+		return "", fmt.Errorf("Value: invalid type %T, must be *groupedFileInfo", item)
+	}
+	return info.groupKey, nil
+}
+
+func (*GroupKeyGetter) ValueString(colName string, item any) (string, error) {
+	info, ok := item.(*groupedFileInfo)
+	if !ok {
+		return "", fmt.Errorf("ValueString: invalid type %T, must be *groupedFileInfo", item)
+	}
+	return app.FormatValue(colName, info.groupKey)
+}
+
+func (*GroupKeyGetter) Format(_ any, value any) (string, error) {
+	return fmt.Sprintf("%v", value), nil
+}
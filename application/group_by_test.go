@@ -0,0 +1,69 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestAgeGroup(t *testing.T) {
+	is := is.New(t)
+	lastStartTime := startTime
+	defer func() {
+		startTime = lastStartTime
+	}()
+	// Thursday
+	now := time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)
+	startTime = &now
+	test := func(tm time.Time, group string) {
+		is.AddMsg("tm=%v", tm).Equal(ageGroup(&tm), group)
+	}
+	test(now.Add(time.Minute), "future")
+	test(now, "today")
+	test(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), "today")
+	test(time.Date(2026, 10, 14, 23, 59, 0, 0, time.UTC), "this week")
+	test(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), "this week")
+	test(time.Date(2026, 10, 11, 23, 59, 0, 0, time.UTC), "this month")
+	test(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), "this month")
+	test(time.Date(2026, 9, 30, 23, 59, 0, 0, time.UTC), "older")
+	is.Equal(ageGroup(nil), "")
+}
+
+func TestGrouperCompareKeys(t *testing.T) {
+	is := is.New(t)
+	g := &grouper{order: ageGroups}
+	is.Equal(g.compareKeys("today", "older") < 0, true)
+	is.Equal(g.compareKeys("older", "future") > 0, true)
+	is.Equal(g.compareKeys("", "today") > 0, true)
+	g = &grouper{}
+	is.Equal(g.compareKeys(".go", ".md") < 0, true)
+	is.Equal(g.compareKeys(".md", ".md"), 0)
+}
+
+func TestGrouperSplit(t *testing.T) {
+	is := is.New(t)
+	g := &grouper{
+		key: func(FileInfo) string {
+			t.Fatal("key must not be computed again in Split")
+			return ""
+		},
+	}
+	item := func(name string, size int64, key string) *DisplayItem {
+		return &DisplayItem{
+			FileInfo: &FileInfoImp{FileInfo: &FileInfoLow{name: name, size: size}},
+			GroupKey: key,
+		}
+	}
+	groups := g.Split([]*DisplayItem{
+		item("b.md", 1, ".md"),
+		item("a.go", 2, ".go"),
+		item("c.md", 3, ".md"),
+	}, false)
+	is.Equal(len(groups), 2)
+	is.Equal(groups[0].key, ".go")
+	is.Equal(len(groups[0].items), 1)
+	is.Equal(groups[1].key, ".md")
+	is.Equal(len(groups[1].items), 2)
+	is.Equal(groups[1].size, uint64(4))
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// escapeURLPath percent-encodes a path for a file:// URL, like GNU ls:
+// only letters, digits, "-", ".", "_", "~" and "/" are kept
+func escapeURLPath(path string) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		b := path[i]
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+			sb.WriteByte(b)
+		case b == '-', b == '.', b == '_', b == '~', b == '/':
+			sb.WriteByte(b)
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hex[b>>4])
+			sb.WriteByte(hex[b&0xf])
+		}
+	}
+	return sb.String()
+}
+
+// isDriveLetter returns true if path starts with a windows drive like "C:"
+func isDriveLetter(path string) bool {
+	if len(path) < 2 || path[1] != ':' {
+		return false
+	}
+	b := path[0]
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// fileURL returns "file://host/path" URL for given absolute path
+func fileURL(host string, path string) string {
+	path = filepath.ToSlash(path)
+	if isDriveLetter(path) {
+		// windows path like C:/dir, ":" of drive is not escaped: file:///C:/dir
+		return "file://" + escapeURLPath(host) + "/" + path[:2] + escapeURLPath(path[2:])
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "file://" + escapeURLPath(host) + escapeURLPath(path)
+}
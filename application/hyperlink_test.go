@@ -0,0 +1,18 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestFileURL(t *testing.T) {
+	is := is.New(t)
+	is.Equal(fileURL("host", "/home/user/a.txt"), "file://host/home/user/a.txt")
+	is.Equal(fileURL("", "/tmp/a b#1?.txt"), "file:///tmp/a%20b%231%3F.txt")
+	is.Equal(fileURL("h", "/x/100%_~-.go"), "file://h/x/100%25_~-.go")
+	is.Equal(fileURL("h", "/x/ü"), "file://h/x/%C3%BC")
+	is.Equal(fileURL("h", "/x/\x1b]"), "file://h/x/%1B%5D")
+	is.Equal(fileURL("", "C:/Users/a b"), "file:///C:/Users/a%20b")
+	is.Equal(fileURL("h", "d:/x:y"), "file://h/d:/x%3Ay")
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"io/fs"
+	"strings"
+
+	c "github.com/ilius/ls-go/common"
+)
+
+// fileIndicator returns the indicator appended to name of a file with
+// given mode, for given indicator style (like GNU ls)
+func fileIndicator(mode fs.FileMode, style string) string {
+	switch style {
+	case c.I_slash:
+		if mode&fs.ModeDir != 0 {
+			return "/"
+		}
+		return ""
+	case c.I_file_type, c.I_classify:
+	default:
+		return ""
+	}
+	switch {
+	case mode&fs.ModeDir != 0:
+		return "/"
+	case mode&fs.ModeSymlink != 0:
+		return "@"
+	case mode&fs.ModeNamedPipe != 0:
+		return "|"
+	case mode&fs.ModeSocket != 0:
+		return "="
+	}
+	if style == c.I_classify && mode&0o111 != 0 && mode&fs.ModeType == 0 {
+		return "*"
+	}
+	return ""
+}
+
+// nameIndicator returns the indicator for name of file
+// if link targets are shown, the indicator is shown after target instead
+// of "@" (like `ls -lF`)
+func (p *FileNameParams) nameIndicator(info FileInfo) string {
+	if p.showLinks && info.Mode()&fs.ModeSymlink != 0 {
+		return ""
+	}
+	return fileIndicator(info.Mode(), p.indicatorStyle)
+}
+
+// linkTargetIndicator returns the indicator for target of a symlink
+func (p *FileNameParams) linkTargetIndicator(link *LinkInfo) string {
+	if link == nil || link.broken {
+		return ""
+	}
+	if link.info != nil {
+		return fileIndicator(link.info.Mode(), p.indicatorStyle)
+	}
+	if link.isDir {
+		return fileIndicator(fs.ModeDir, p.indicatorStyle)
+	}
+	return ""
+}
+
+// appendIndicator appends indicator to (possibly colored) name, before
+// trailing spaces that are used as padding (like " dir " in its color)
+func appendIndicator(name string, indicator string) string {
+	if indicator == "" {
+		return name
+	}
+	trimmed := strings.TrimRight(name, " ")
+	padding := name[len(trimmed):]
+	if strings.HasSuffix(trimmed, " "+Reset) {
+		return trimmed[:len(trimmed)-len(Reset)-1] + indicator + " " + Reset + padding
+	}
+	return trimmed + indicator + padding
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/ilius/is/v2"
+
+	c "github.com/ilius/ls-go/common"
+)
+
+func TestFileIndicator(t *testing.T) {
+	is := is.New(t)
+	modes := []fs.FileMode{
+		0o644,
+		0o755,
+		fs.ModeDir | 0o755,
+		fs.ModeSymlink | 0o777,
+		fs.ModeNamedPipe | 0o644,
+		fs.ModeSocket | 0o755,
+		fs.ModeDevice | 0o660,
+	}
+	indicators := func(style string) []string {
+		result := make([]string, len(modes))
+		for i, mode := range modes {
+			result[i] = fileIndicator(mode, style)
+		}
+		return result
+	}
+	is.Equal(indicators(c.I_none), []string{"", "", "", "", "", "", ""})
+	is.Equal(indicators(c.I_slash), []string{"", "", "/", "", "", "", ""})
+	is.Equal(indicators(c.I_file_type), []string{"", "", "/", "@", "|", "=", ""})
+	is.Equal(indicators(c.I_classify), []string{"", "*", "/", "@", "|", "=", ""})
+}
+
+func TestAppendIndicator(t *testing.T) {
+	is := is.New(t)
+	is.Equal(appendIndicator("a.txt", ""), "a.txt")
+	is.Equal(appendIndicator("a.sh", "*"), "a.sh*")
+	is.Equal(appendIndicator("\x1b[1m dir \x1b[0m", "/"), "\x1b[1m dir/ \x1b[0m")
+	is.Equal(appendIndicator("\x1b[1m dir \x1b[0m ", "@"), "\x1b[1m dir@ \x1b[0m ")
+	is.Equal(appendIndicator("\x1b[1mlink\x1b[0m", "@"), "\x1b[1mlink\x1b[0m@")
+	is.Equal(appendIndicator("🔗 link ", "@"), "🔗 link@ ")
+}
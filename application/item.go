@@ -1,12 +1,21 @@
 package application
 
-import "time"
+import (
+	"time"
+
+	"github.com/ilius/ls-go/lscolors"
+)
 
 // DisplayItem wraps the file stat info and string to be printed
 type DisplayItem struct {
 	FileInfo
 	Time    *time.Time
 	Display []string
+	// row highlight set by color rules, or nil
+	Highlight *lscolors.Style
+	// GroupKey is the key of group (with --group-by), computed once
+	// before formatting the item
+	GroupKey string
 }
 
 type DisplayItemList []*DisplayItem
@@ -18,3 +27,7 @@ func (list DisplayItemList) Len() int {
 func (list DisplayItemList) Get(index int) []string {
 	return list[index].Display
 }
+
+func (list DisplayItemList) Highlight(index int) *lscolors.Style {
+	return list[index].Highlight
+}
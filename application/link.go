@@ -29,6 +29,7 @@ func (link *LinkInfo) Dereference(source FileInfo) FileInfo {
 		basename: pname.Base,
 		ext:      pname.Ext,
 		suffix:   pname.Suffix,
+		depth:    source.Depth(),
 	}
 }
 
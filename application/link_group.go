@@ -0,0 +1,127 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+
+	c "github.com/ilius/ls-go/common"
+)
+
+// devInode identifies a file across the whole listing
+type devInode struct {
+	dev   uint64
+	inode uint64
+}
+
+// fileDevInode returns (dev, inode) of a file that has more than one hard link
+// second return value is false for directories and files with a single link
+func fileDevInode(info FileInfo) (devInode, bool) {
+	if info.IsDir() {
+		return devInode{}, false
+	}
+	count, err := info.NumberOfHardLinks()
+	if err != nil || count < 2 {
+		return devInode{}, false
+	}
+	dev, err := info.DeviceID()
+	if err != nil {
+		return devInode{}, false
+	}
+	inode, err := info.Inode()
+	if err != nil {
+		return devInode{}, false
+	}
+	return devInode{dev: dev, inode: inode}, true
+}
+
+// linkGroupTracker gives a short tag to every (dev, inode) pair with more
+// than one hard link, in the order they appear in the whole listing (including -R)
+// and counts the bytes saved by listed hard links
+type linkGroupTracker struct {
+	tags  map[devInode]string
+	links int
+	saved uint64
+}
+
+func newLinkGroupTracker() *linkGroupTracker {
+	return &linkGroupTracker{
+		tags: map[devInode]string{},
+	}
+}
+
+// Add must be called once for every listed item, before formatting it
+func (t *linkGroupTracker) Add(info FileInfo) {
+	key, ok := fileDevInode(info)
+	if !ok {
+		return
+	}
+	if _, seen := t.tags[key]; seen {
+		t.links++
+		t.saved += uint64(info.Size())
+		return
+	}
+	t.tags[key] = "#" + strconv.Itoa(len(t.tags)+1)
+}
+
+func (t *linkGroupTracker) Tag(info FileInfo) string {
+	key, ok := fileDevInode(info)
+	if !ok {
+		return ""
+	}
+	return t.tags[key]
+}
+
+func NewLinkGroupGetter(colors bool, sep string) *LinkGroupGetter {
+	return &LinkGroupGetter{
+		colors: colors,
+		sep:    sep,
+	}
+}
+
+// LinkGroupGetter shows the same tag for items sharing (dev, inode)
+// with --json, it also adds dev_inode pair for every item
+type LinkGroupGetter struct {
+	colors bool
+	sep    string
+}
+
+func (f *LinkGroupGetter) Value(item any) (any, error) {
+	info, ok := item.(FileInfo)
+	if !ok {
+		return "", fmt.Errorf("Value: invalid type %T, must be FileInfo", item)
+	}
+	return app.linkGroups.Tag(info), nil
+}
+
+func (f *LinkGroupGetter) ValueString(colName string, item any) (string, error) {
+	info, ok := item.(FileInfo)
+	if !ok {
+		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
+	}
+	tagStr, err := app.FormatValue(colName, app.linkGroups.Tag(info))
+	if err != nil {
+		return "", err
+	}
+	dev, err := info.DeviceID()
+	if err != nil {
+		app.AddError(err)
+	}
+	inode, err := info.Inode()
+	if err != nil {
+		app.AddError(err)
+	}
+	pairStr, err := app.FormatValue(c.C_DevInode, [2]uint64{dev, inode})
+	if err != nil {
+		return "", err
+	}
+	return tagStr + f.sep + pairStr, nil
+}
+
+func (f *LinkGroupGetter) Format(_ any, value any) (string, error) {
+	// _: item is FileInfo, value is string returned by .Value(item)
+	tag := value.(string)
+	if !f.colors || tag == "" {
+		return tag, nil
+	}
+	return app.Colorize(tag, colors.LinkGroup), nil
+}
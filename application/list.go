@@ -1,11 +1,9 @@
 package application
 
 import (
-	"fmt"
 	"io/fs"
 	"os"
 	"regexp"
-	"strconv"
 
 	"github.com/ilius/go-table"
 	jsonparse "github.com/ilius/ls-go/parse/json"
@@ -17,7 +15,7 @@ func (app *Application) ListMain(tableSpec *table.TableSpec) {
 		check(err)
 		app.ListFiles(
 			table.NewTable(tableSpec),
-			app.WorkDir(),
+			"", // not listing a directory
 			res.Files,
 			true, // forceDotfiles
 		)
@@ -53,7 +51,7 @@ func (app *Application) ListMain(tableSpec *table.TableSpec) {
 	if len(files) > 0 {
 		app.ListFiles(
 			table.NewTable(tableSpec),
-			app.WorkDir(),
+			"", // not listing a directory
 			files,
 			true, // forceDotfiles
 		)
@@ -72,15 +70,17 @@ func (app *Application) ListDirList(tableSpec *table.TableSpec, pathList []strin
 	}
 	count := len(pathList)
 	for _, path := range pathList[:count-1] {
-		count := app.ListDir(table.NewTable(tableSpec), path)
+		count := app.ListDir(table.NewTable(tableSpec), path, 0)
 		if count > 0 {
 			app.FolderTail(stdout, path)
 		}
 	}
-	app.ListDir(table.NewTable(tableSpec), pathList[count-1])
+	app.ListDir(table.NewTable(tableSpec), pathList[count-1], 0)
 }
 
-func (app *Application) ListDir(tableObj *table.Table, path string) int {
+// ListDir lists contents of directory at path, depth is the depth of
+// directory (0 for path arguments, see FileInfo.Depth)
+func (app *Application) ListDir(tableObj *table.Table, path string, depth int) int {
 	items := []FileInfo{}
 
 	pathAbs, err := app.FileSystem.Abs(path)
@@ -96,6 +96,7 @@ func (app *Application) ListDir(tableObj *table.Table, path string) int {
 			dir:      pathAbs,
 			curDir:   pathAbs,
 			isAbs:    false,
+			depth:    depth + 1,
 		})
 	}
 
@@ -139,6 +140,10 @@ func (app *Application) ListDir(tableObj *table.Table, path string) int {
 
 	if len(items) > 0 {
 		app.ListFiles(tableObj, path, items, false)
+	} else {
+		summary := app.newFolderSummary(path)
+		app.printTotal(summary)
+		app.printSummary(summary)
 	}
 
 	count := len(items)
@@ -149,7 +154,7 @@ func (app *Application) ListDir(tableObj *table.Table, path string) int {
 				if count > 0 {
 					app.FolderTail(stdout, item.Name())
 				}
-				count = app.ListDir(tableObj, app.FileSystem.Join(path, item.Name()))
+				count = app.ListDir(tableObj, app.FileSystem.Join(path, item.Name()), depth+1)
 			}
 		}
 	}
@@ -157,8 +162,9 @@ func (app *Application) ListDir(tableObj *table.Table, path string) int {
 	return count
 }
 
-func (app *Application) ListFiles(tableObj *table.Table, _ string, infoList []FileInfo, forceDotfiles bool) {
-	// args: tableObj, parentDir, infoList, forceDotfiles
+// ListFiles formats and prints given items
+// parentDir is the path of directory being listed, or empty for file arguments
+func (app *Application) ListFiles(tableObj *table.Table, parentDir string, infoList []FileInfo, forceDotfiles bool) {
 	if *args.All || *args.AlmostAll {
 		forceDotfiles = true
 	}
@@ -173,18 +179,40 @@ func (app *Application) ListFiles(tableObj *table.Table, _ string, infoList []Fi
 	files := []*DisplayItem{}
 	pinDirs := []*DisplayItem{}
 
+	linkGroups := app.linkGroups
+	linksBefore, savedBefore := 0, uint64(0)
+	if linkGroups != nil {
+		linksBefore, savedBefore = linkGroups.links, linkGroups.saved
+	}
+
 	renderItem := func(info FileInfo) *DisplayItem {
-		display, err := app.FormatItem(tableObj, info)
-		check(err)
-		return &DisplayItem{
+		if linkGroups != nil {
+			linkGroups.Add(info)
+		}
+		item := &DisplayItem{
 			FileInfo: info,
 			Time:     info.Time(app.PrimaryTimeColName),
-			Display:  display,
 		}
+		var formatItem any = info
+		if app.grouper != nil {
+			item.GroupKey = app.grouper.key(info)
+			formatItem = &groupedFileInfo{FileInfo: info, groupKey: item.GroupKey}
+		}
+		display, err := app.FormatItem(tableObj, formatItem)
+		check(err)
+		item.Display = display
+		if hasHighlightRules {
+			if match := matchColorRules(info, true); match != nil {
+				item.Highlight = match.highlight
+			}
+		}
+		return item
 	}
-	addCondCount := 0
+	filters := app.filters
 	add := func(info FileInfo) {
-		files = append(files, renderItem(info))
+		if matchFilters(filters, info) {
+			files = append(files, renderItem(info))
+		}
 	}
 	addFile := func(info FileInfo) {
 		add(info)
@@ -192,76 +220,13 @@ func (app *Application) ListFiles(tableObj *table.Table, _ string, infoList []Fi
 	addDir := func(info FileInfo) {
 		add(info)
 	}
-	if *args.Minsize > 0 {
-		minsize := int64(*args.Minsize)
-		if *args.Maxsize > 0 {
-			maxsize := int64(*args.Maxsize)
-			add = func(info FileInfo) {
-				if info.Size() >= minsize && info.Size() <= maxsize {
-					files = append(files, renderItem(info))
-				}
-			}
-		} else {
-			add = func(info FileInfo) {
-				if info.Size() >= minsize {
-					files = append(files, renderItem(info))
-				}
-			}
-		}
-		addCondCount++
-	} else if *args.Maxsize > 0 {
-		maxsize := int64(*args.Maxsize)
-		add = func(info FileInfo) {
-			if info.Size() <= maxsize {
-				files = append(files, renderItem(info))
-			}
-		}
-		addCondCount++
-	}
-	if *args.Where != "" {
-		getter := NewExprGetter(false, *args.Where)
-		if addCondCount == 0 {
-			add = func(info FileInfo) {
-				if getter.MustValueBool(info) {
-					files = append(files, renderItem(info))
-				}
-			}
-		} else {
-			lastAdd := add
-			add = func(info FileInfo) {
-				if getter.MustValueBool(info) {
-					lastAdd(info)
-				}
-			}
-		}
-		// addCondCount++
-	}
-	if *args.HasMode != "" {
-		mode, err := strconv.ParseUint(*args.HasMode, 8, 16)
-		if err != nil {
-			panic(fmt.Errorf("--has-mode: bad octal mode %#v", *args.HasMode))
-		}
-		if addCondCount == 0 {
-			add = func(info FileInfo) {
-				if uint64(info.Mode())&mode == mode {
-					files = append(files, renderItem(info))
-				}
-			}
-		} else {
-			lastAdd := add
-			add = func(info FileInfo) {
-				if uint64(info.Mode())&mode == mode {
-					lastAdd(info)
-				}
-			}
-		}
-		// addCondCount++
-	}
 	if filesOnly {
 		addDir = func(FileInfo) {}
 	} else if dirsFirst {
 		addDir = func(info FileInfo) {
-			pinDirs = append(pinDirs, renderItem(info))
+			if matchFilters(filters, info) {
+				pinDirs = append(pinDirs, renderItem(info))
+			}
 		}
 	}
 	if dirsOnly {
@@ -310,24 +275,40 @@ func (app *Application) ListFiles(tableObj *table.Table, _ string, infoList []Fi
 		addFile(info)
 	}
 
+	summary := app.newFolderSummary(parentDir, pinDirs, files)
+	app.printTotal(summary)
+
 	// print header after formatting/rendering all items (tableObj.FormatItem)
 	// so that we know the width of every column
 
 	app.TableHeader(stdout, tableObj)
 
-	sortFiles(files, *args.Sort, *args.Reverse)
-	sortDirs(pinDirs, *args.Sort, *args.Reverse)
+	sortFiles(files, app.sortKeys, *args.Reverse)
+	sortDirs(pinDirs, app.sortKeys, *args.Reverse)
 
 	// combine the items together again after sorting, then format and print
-	check(app.PrintItems(
-		stdout,
-		tableObj,
-		DisplayItemList(append(pinDirs, files...)),
-	))
+	if app.grouper != nil {
+		app.printGroups(tableObj, append(pinDirs, files...))
+	} else {
+		check(app.PrintItems(
+			stdout,
+			tableObj,
+			DisplayItemList(append(pinDirs, files...)),
+		))
+	}
+
+	app.printSummary(summary)
 
 	if *args.Stats {
 		colorsEnable, err := app.Terminal.ColorsEnabled(*args.Color)
 		check(err)
 		printStats(colorsEnable, len(files), len(pinDirs))
+		if linkGroups != nil && linkGroups.links > linksBefore {
+			printLinkStats(
+				colorsEnable,
+				linkGroups.links-linksBefore,
+				linkGroups.saved-savedBefore,
+			)
+		}
 	}
 }
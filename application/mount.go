@@ -0,0 +1,149 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lsplatform"
+)
+
+const mountMarker = "⏏"
+
+// mountTable is loaded once (with --mounts) and used to find
+// mount point and filesystem of each item
+type mountTable struct {
+	list    []*lsplatform.MountInfo
+	byPoint map[string]*lsplatform.MountInfo
+}
+
+func newMountTable(list []*lsplatform.MountInfo) *mountTable {
+	byPoint := make(map[string]*lsplatform.MountInfo, len(list))
+	for _, mount := range list {
+		// later entries are mounted on top of former ones
+		byPoint[mount.MountPoint] = mount
+	}
+	return &mountTable{
+		list:    list,
+		byPoint: byPoint,
+	}
+}
+
+// Find returns the mount that contains given absolute path
+func (mt *mountTable) Find(pathAbs string) *lsplatform.MountInfo {
+	if mount, ok := mt.byPoint[pathAbs]; ok {
+		return mount
+	}
+	var best *lsplatform.MountInfo
+	for _, mount := range mt.list {
+		mp := mount.MountPoint
+		if mp != "/" && !strings.HasPrefix(pathAbs, mp+"/") {
+			continue
+		}
+		if best == nil || len(mp) >= len(best.MountPoint) {
+			best = mount
+		}
+	}
+	return best
+}
+
+// IsMountPoint returns true if given absolute path is a mount point
+func (mt *mountTable) IsMountPoint(pathAbs string) bool {
+	_, ok := mt.byPoint[pathAbs]
+	return ok
+}
+
+func (app *Application) loadMounts() {
+	list, err := app.Platform.Mounts()
+	if err != nil {
+		app.AddError(err)
+	}
+	app.mounts = newMountTable(list)
+}
+
+func isMountPoint(info FileInfo) bool {
+	if app.mounts == nil || !info.IsDir() {
+		return false
+	}
+	return app.mounts.IsMountPoint(info.PathAbs())
+}
+
+// DiskUsage returns free and total space of the filesystem containing path
+func (app *Application) DiskUsage(path string) (*c.DiskUsage, error) {
+	pathAbs, err := app.FileSystem.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return app.Platform.DiskUsage(pathAbs)
+}
+
+func NewFilesystemGetter(colors bool, sep string) *FilesystemGetter {
+	return &FilesystemGetter{
+		colors: colors,
+		sep:    sep,
+	}
+}
+
+// FilesystemGetter shows filesystem type and mount source of the filesystem
+// that contains the item. with --json, it also adds mount_point boolean
+type FilesystemGetter struct {
+	colors bool
+	sep    string
+}
+
+func (f *FilesystemGetter) mount(info FileInfo) *lsplatform.MountInfo {
+	if app.mounts == nil {
+		return nil
+	}
+	return app.mounts.Find(info.PathAbs())
+}
+
+func (f *FilesystemGetter) Value(item any) (any, error) {
+	info, ok := item.(FileInfo)
+	if !ok {
+		return "", fmt.Errorf("Value: invalid type %T, must be FileInfo", item)
+	}
+	return f.mount(info), nil
+}
+
+func (f *FilesystemGetter) ValueString(colName string, item any) (string, error) {
+	info, ok := item.(FileInfo)
+	if !ok {
+		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
+	}
+	fsType := ""
+	source := ""
+	if mount := f.mount(info); mount != nil {
+		fsType = mount.FsType
+		source = mount.Source
+	}
+	fsTypeStr, err := app.FormatValue(colName, fsType)
+	if err != nil {
+		return "", err
+	}
+	sourceStr, err := app.FormatValue(c.C_MountSource, source)
+	if err != nil {
+		return "", err
+	}
+	mountPointStr, err := app.FormatValue(c.C_MountPoint, isMountPoint(info))
+	if err != nil {
+		return "", err
+	}
+	return fsTypeStr + f.sep + sourceStr + f.sep + mountPointStr, nil
+}
+
+func (f *FilesystemGetter) Format(_ any, value any) (string, error) {
+	// _: item: not used
+	mount, ok := value.(*lsplatform.MountInfo)
+	if !ok {
+		return "", fmt.Errorf("Format: invalid value type %T, must be *MountInfo", value)
+	}
+	if mount == nil {
+		return "", nil
+	}
+	if !f.colors {
+		return mount.FsType + " " + mount.Source, nil
+	}
+	return app.Colorize(mount.FsType, colors.Mount.FsType) + " " +
+		app.Colorize(mount.Source, colors.Mount.Source), nil
+}
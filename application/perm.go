@@ -0,0 +1,135 @@
+package application
+
+import (
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// unixPerm returns permission bits of mode like chmod, with SUID, SGID and
+// sticky as 04000, 02000 and 01000 (fs.FileMode has them in other bits)
+func unixPerm(mode fs.FileMode) uint32 {
+	perm := uint32(mode.Perm())
+	if mode&fs.ModeSetuid != 0 {
+		perm |= 0o4000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		perm |= 0o2000
+	}
+	if mode&fs.ModeSticky != 0 {
+		perm |= 0o1000
+	}
+	return perm
+}
+
+// permWhoMasks are bits of u, g and o in symbolic modes
+var permWhoMasks = map[byte]uint32{
+	'u': 0o4700,
+	'g': 0o2070,
+	'o': 0o1007,
+	'a': 0o7777,
+}
+
+// permBits are bits of permission letters in symbolic modes, for all of u, g and o
+var permBits = map[byte]uint32{
+	'r': 0o444,
+	'w': 0o222,
+	'x': 0o111,
+	'X': 0o111,
+	's': 0o6000,
+	't': 0o1000,
+}
+
+// parseSymbolicMode parses a symbolic mode like "u+x,g-w" or "a=rw",
+// starting from zero mode (like find)
+func parseSymbolicMode(str string) (uint32, error) {
+	mode := uint32(0)
+	for _, clause := range strings.Split(str, ",") {
+		i := 0
+		who := uint32(0)
+		for ; i < len(clause); i++ {
+			mask, ok := permWhoMasks[clause[i]]
+			if !ok {
+				break
+			}
+			who |= mask
+		}
+		if who == 0 {
+			who = permWhoMasks['a']
+		}
+		if i == len(clause) {
+			return 0, fmt.Errorf("invalid mode %#v", str)
+		}
+		for i < len(clause) {
+			op := clause[i]
+			if op != '+' && op != '-' && op != '=' {
+				return 0, fmt.Errorf("invalid mode %#v", str)
+			}
+			i++
+			bits := uint32(0)
+			for ; i < len(clause); i++ {
+				letterBits, ok := permBits[clause[i]]
+				if !ok {
+					break
+				}
+				bits |= letterBits
+			}
+			bits &= who
+			switch op {
+			case '+':
+				mode |= bits
+			case '-':
+				mode &^= bits
+			case '=':
+				mode = mode&^who | bits
+			}
+		}
+	}
+	return mode, nil
+}
+
+// parseMode parses an octal mode like "755" or a symbolic mode like "u+x"
+func parseMode(str string) (uint32, error) {
+	if str != "" && str[0] >= '0' && str[0] <= '9' {
+		mode, err := strconv.ParseUint(str, 8, 32)
+		if err != nil || mode > 0o7777 {
+			return 0, fmt.Errorf("invalid octal mode %#v", str)
+		}
+		return uint32(mode), nil
+	}
+	return parseSymbolicMode(str)
+}
+
+// parsePerm parses value of --perm, like find:
+// "MODE" for exactly MODE, "-MODE" for all bits of MODE,
+// and "/MODE" for any bits of MODE (or any file if MODE is zero)
+// MODE can be octal like "644" or symbolic like "u+x,g-w"
+// returns a function that checks permission bits returned by unixPerm
+func parsePerm(str string) (func(perm uint32) bool, error) {
+	switch {
+	case strings.HasPrefix(str, "-"):
+		mode, err := parseMode(str[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(perm uint32) bool {
+			return perm&mode == mode
+		}, nil
+	case strings.HasPrefix(str, "/"):
+		mode, err := parseMode(str[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(perm uint32) bool {
+			return mode == 0 || perm&mode != 0
+		}, nil
+	}
+	mode, err := parseMode(str)
+	if err != nil {
+		return nil, err
+	}
+	return func(perm uint32) bool {
+		return perm == mode
+	}, nil
+}
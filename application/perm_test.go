@@ -0,0 +1,66 @@
+package application
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestUnixPerm(t *testing.T) {
+	is := is.New(t)
+	is.Equal(unixPerm(0o644), uint32(0o644))
+	is.Equal(unixPerm(fs.ModeDir|fs.ModeSticky|0o777), uint32(0o1777))
+	is.Equal(unixPerm(fs.ModeSetuid|0o755), uint32(0o4755))
+	is.Equal(unixPerm(fs.ModeSetgid|0o2755), uint32(0o2755))
+}
+
+func TestParseMode(t *testing.T) {
+	is := is.New(t)
+	test := func(str string, expected uint32) {
+		is := is.AddMsg("str=%#v", str)
+		mode, err := parseMode(str)
+		is.NotErr(err)
+		is.Equal(mode, expected)
+	}
+	test("644", 0o644)
+	test("4755", 0o4755)
+	test("u+x", 0o100)
+	test("u+x,g-w", 0o100)
+	test("ug+rw", 0o660)
+	test("a=r,u+w", 0o644)
+	test("+x", 0o111)
+	test("u=rwx,go=rx", 0o755)
+	test("u+s", 0o4000)
+	test("a+rwx,o-w", 0o775)
+	test("o+t", 0o1000)
+	for _, str := range []string{"", "9", "17777", "u", "u+y", "q+x", "u+x,", "u=g"} {
+		_, err := parseMode(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
+
+func TestParsePerm(t *testing.T) {
+	is := is.New(t)
+	test := func(str string, perm uint32, expected bool) {
+		match, err := parsePerm(str)
+		is := is.AddMsg("str=%#v, perm=%o", str, perm)
+		is.NotErr(err)
+		is.Equal(match(perm), expected)
+	}
+	test("644", 0o644, true)
+	test("644", 0o664, false)
+	test("-644", 0o664, true)
+	test("-644", 0o640, false)
+	test("-u+x", 0o755, true)
+	test("-u+x", 0o644, false)
+	test("/111", 0o644, false)
+	test("/111", 0o654, true)
+	test("/o+w,g+w", 0o664, true)
+	test("/000", 0o600, true)
+	test("-g+w,u-w", 0o664, true)
+	for _, str := range []string{"", "-", "/", "-x", "/abc", "-8"} {
+		_, err := parsePerm(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
@@ -2,7 +2,9 @@ package application
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	c "github.com/ilius/ls-go/common"
 )
@@ -25,6 +27,8 @@ func quoteFileName(in string) string {
 		return quoteC(in)
 	case c.E_escape:
 		return quoteEscape(in)
+	case c.E_locale:
+		return quoteLocale(in)
 	}
 	return in
 }
@@ -112,3 +116,32 @@ func quoteEscape(in string) string {
 	in = strings.ReplaceAll(in, "\n", `\n`)
 	return in
 }
+
+// quoteLocale always quotes with quotation marks of locale (LC_MESSAGES)
+// and escapes backslash and nongraphic characters like C, and the
+// closing quotation mark if it is ASCII
+func quoteLocale(in string) string {
+	quoteOpen, quoteClose := app.LocaleQuoteOpen, app.LocaleQuoteClose
+	escapeClose := len(quoteClose) == 1
+	var b strings.Builder
+	b.WriteString(quoteOpen)
+	for i := 0; i < len(in); {
+		r, size := utf8.DecodeRuneInString(in[i:])
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case escapeClose && string(r) == quoteClose:
+			b.WriteString(`\` + quoteClose)
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, `\%03o`, in[i])
+		case !strconv.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			b.WriteString(in[i : i+size])
+		}
+		i += size
+	}
+	b.WriteString(quoteClose)
+	return b.String()
+}
@@ -106,4 +106,18 @@ func Test_quoteFileName(t *testing.T) {
 	test(8, "a\nb", `a\nb`)
 	test(9, `a\b`, `a\\b`)
 	test(-1, "README.md", "README.md")
+
+	app.QuotingStyle = E_locale
+	app.LocaleQuoteOpen, app.LocaleQuoteClose = "'", "'"
+	test(0, "test", `'test'`)
+	test(1, "a b", `'a b'`)
+	test(3, "a'b", `'a\'b'`)
+	test(4, `a"b`, `'a"b'`)
+	test(7, "a\tb", `'a\tb'`)
+	test(9, `a\b`, `'a\\b'`)
+	test(10, "a\xffb", `'a\377b'`)
+	app.LocaleQuoteOpen, app.LocaleQuoteClose = "„", "“"
+	test(0, "test", "„test“")
+	test(3, "a'b", "„a'b“")
+	test(8, "a\nb", `„a\nb“`)
 }
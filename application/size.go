@@ -3,13 +3,12 @@ package application
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/ilius/go-table"
-	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lssize"
 )
 
-func NewSizeGetter(colors bool, format c.SizeFormat) table.Getter {
+func NewSizeGetter(colors bool, format *lssize.Format) table.Getter {
 	if colors {
 		return &SizeGetter{format: format}
 	}
@@ -17,37 +16,7 @@ func NewSizeGetter(colors bool, format c.SizeFormat) table.Getter {
 }
 
 type SizeGetter struct {
-	format c.SizeFormat
-}
-
-// use metric system (or SI) to format size, powers of 1000
-func (f *SizeGetter) sizeStringMetric(size uint64) string {
-	if size < 1000 {
-		sizeStr := strconv.FormatUint(size, 10)
-		return app.Colorize(sizeStr+"B ", colors.Size["B"])
-	}
-	for _, unit := range sizeUnits {
-		if unit.Next == nil || unit.Next.Metric > size {
-			sizeStr := formatSizeByBase(size, unit.Metric)
-			return app.Colorize(sizeStr+unit.Symbol+" ", colors.Size[unit.Symbol])
-		}
-	}
-	return strconv.FormatUint(size, 10)
-}
-
-// use powers of 1024
-func (f *SizeGetter) sizeStringLegacy(size uint64) string {
-	if size < 1024 {
-		sizeStr := strconv.FormatUint(size, 10)
-		return app.Colorize(sizeStr+"B ", colors.Size["B"])
-	}
-	for _, unit := range sizeUnits {
-		if unit.Next == nil || unit.Next.Legacy > size {
-			sizeStr := formatSizeByBase(size, unit.Legacy)
-			return app.Colorize(sizeStr+unit.Symbol+" ", colors.Size[unit.Symbol])
-		}
-	}
-	return strconv.FormatUint(size, 10)
+	format *lssize.Format
 }
 
 func (f *SizeGetter) Value(item any) (any, error) {
@@ -79,13 +48,9 @@ func (f *SizeGetter) Format(item any, value any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("Format: invalid value type %T, must be uint64", value)
 	}
-	switch f.format {
-	case c.SizeFormatInteger:
-		return strconv.FormatUint(size, 10), nil
-	case c.SizeFormatMetric:
-		return f.sizeStringMetric(size), nil
-	case c.SizeFormatLegacy:
-		return f.sizeStringLegacy(size), nil
+	str, key := f.format.Format(size)
+	if key == "" {
+		return str, nil
 	}
-	return "", fmt.Errorf("invalid size format %v", f.format)
+	return app.Colorize(str+" ", colors.Size.Get(key)), nil
 }
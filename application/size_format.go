@@ -0,0 +1,62 @@
+package application
+
+import (
+	"fmt"
+
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/iface"
+	"github.com/ilius/ls-go/lssize"
+)
+
+// makeSizeFormats returns format of size column, and format of blocks
+// column and "total" line, from --block-size, -k, --iec and --size-decimals
+// -h / --si / --bytes override --block-size for sizes, like GNU ls
+// (where the last one wins), and machine-readable formats (json and csv)
+// always use bytes for sizes and numbers (not human-readable) for blocks
+func makeSizeFormats(formatter iface.Formatter) (*lssize.Format, *lssize.Format, error) {
+	var blockSize *lssize.Format
+	if *args.BlockSize != "" {
+		var err error
+		blockSize, err = lssize.ParseBlockSize(*args.BlockSize)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	human := func(base uint64) *lssize.Format {
+		format := lssize.Human(base)
+		format.IEC = *args.IEC
+		format.Decimals = *args.SizeDecimals
+		return format
+	}
+	if blockSize != nil && blockSize.Human {
+		blockSize = human(blockSize.Base)
+	}
+
+	var sizeFormat *lssize.Format
+	switch formatter.SizeFormat() {
+	case c.SizeFormatInteger:
+		sizeFormat = lssize.Bytes()
+	case c.SizeFormatMetric:
+		sizeFormat = human(1000)
+	case c.SizeFormatLegacy:
+		sizeFormat = human(1024)
+		if blockSize != nil && !*args.Human {
+			sizeFormat = blockSize
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid size format %v", formatter.SizeFormat())
+	}
+
+	blocksFormat := &lssize.Format{BlockSize: 1024}
+	switch {
+	case *args.Shortcut_k:
+	case sizeFormat.Human && (*args.SI || *args.Human):
+		blocksFormat = sizeFormat
+	case blockSize != nil:
+		blocksFormat = blockSize
+	}
+	if blocksFormat.Human && formatter.SizeFormat() == c.SizeFormatInteger {
+		blocksFormat = &lssize.Format{BlockSize: 1024}
+	}
+	return sizeFormat, blocksFormat, nil
+}
@@ -1,204 +1,209 @@
 package application
 
 import (
+	"cmp"
 	"strings"
 )
 
-type ItemSorter []*DisplayItem
+// ItemComparator compares two items, returns a negative number if a
+// comes before b, a positive number if b comes before a, and zero
+// if they are equal (by this comparator)
+// it must only use precomputed keys of sortEntry (see sortColumn)
+type ItemComparator func(a *sortEntry, b *sortEntry) int
 
-// DefaultSorter is the default sorter for files and directories
-// it first sorts by lowercased basename, then by extension
-type DefaultSorter ItemSorter
-
-func (s DefaultSorter) Len() int      { return len(s) }
-func (s DefaultSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s DefaultSorter) Less(i, j int) bool {
-	i_base := strings.ToLower(s[i].Basename())
-	j_base := strings.ToLower(s[j].Basename())
-	if i_base != j_base {
-		return i_base < j_base
+func reverseComparator(compare ItemComparator) ItemComparator {
+	return func(a *sortEntry, b *sortEntry) int {
+		return compare(b, a)
 	}
-	return s[i].Ext() < s[j].Ext()
 }
 
-// NameSorter sorts by full name
-type NameSorter ItemSorter
-
-func (s NameSorter) Len() int      { return len(s) }
-func (s NameSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s NameSorter) Less(i, j int) bool {
-	return strings.ToLower(s[i].Name()) < strings.ToLower(s[j].Name())
+// compareDefault is the default comparator for files and directories
+// it first compares lowercased basename, then extension
+func compareDefault(a *sortEntry, b *sortEntry) int {
+	diff := strings.Compare(a.lowerBasename, b.lowerBasename)
+	if diff != 0 {
+		return diff
+	}
+	return strings.Compare(a.Ext(), b.Ext())
 }
 
-type BasenameSorter ItemSorter
-
-func (s BasenameSorter) Len() int      { return len(s) }
-func (s BasenameSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s BasenameSorter) Less(i, j int) bool {
-	return strings.ToLower(s[i].Basename()) < strings.ToLower(s[j].Basename())
+// compareName compares lowercased full names
+func compareName(a *sortEntry, b *sortEntry) int {
+	return strings.Compare(a.lowerName, b.lowerName)
 }
 
-// sort by size in decending order
-type SizeSorter ItemSorter
-
-func (s SizeSorter) Len() int      { return len(s) }
-func (s SizeSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func compareBasename(a *sortEntry, b *sortEntry) int {
+	return strings.Compare(a.lowerBasename, b.lowerBasename)
+}
 
-func (s SizeSorter) Less(i, j int) bool {
-	info1 := s[i]
-	info2 := s[j]
-	if info1.IsDir() && info2.IsDir() {
-		n1, err := app.FileSystem.CountDirContents(info1.PathAbs())
-		check(err)
-		n2, _ := app.FileSystem.CountDirContents(info2.PathAbs())
-		check(err)
-		return n1 > n2
+// compareSize compares by size in decending order
+// and directories by number of contents
+func compareSize(a *sortEntry, b *sortEntry) int {
+	if a.isDir && b.isDir {
+		return cmp.Compare(b.contentsCount, a.contentsCount)
 	}
-	return info1.Size() > info2.Size()
+	return cmp.Compare(b.size, a.size)
 }
 
-// FileSizeSorter sorts files by size, and directories by lowercased name
-// and sort directories after files
-type FileSizeSorter ItemSorter
-
-func (s FileSizeSorter) Len() int      { return len(s) }
-func (s FileSizeSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s FileSizeSorter) Less(i, j int) bool {
-	info1 := s[i]
-	info2 := s[j]
-	if info1.IsDir() {
-		if !info2.IsDir() {
-			return false
+// compareFileSize compares files by size, and directories by lowercased name
+// and puts directories after files
+func compareFileSize(a *sortEntry, b *sortEntry) int {
+	if a.isDir {
+		if !b.isDir {
+			return 1
 		}
-		return strings.ToLower(info1.Name()) < strings.ToLower(info2.Name())
+		return compareName(a, b)
 	}
-	if info2.IsDir() {
-		return true
+	if b.isDir {
+		return -1
 	}
-	return info1.Size() > info2.Size()
+	return cmp.Compare(b.size, a.size)
 }
 
-// sorts by the number of contents (files and directories) in directories
-// directories always come before files
+// compareDirContentsCount compares by the number of contents (files and
+// directories) in directories, directories always come before files
 // and remember, this includes hidden contents as well, so to count
 // manually, use `ls -A1` or `ls-go -a1`
-type DirContentsCountSorter ItemSorter
-
-func (s DirContentsCountSorter) Len() int      { return len(s) }
-func (s DirContentsCountSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s DirContentsCountSorter) Less(i, j int) bool {
-	info1 := s[i]
-	info2 := s[j]
-	if !info1.IsDir() {
-		return false
+func compareDirContentsCount(a *sortEntry, b *sortEntry) int {
+	if !a.isDir {
+		if !b.isDir {
+			return 0
+		}
+		return 1
 	}
-	if !info2.IsDir() {
-		return true
+	if !b.isDir {
+		return -1
 	}
-	n1, _ := app.FileSystem.CountDirContents(info1.PathAbs())
-	n2, _ := app.FileSystem.CountDirContents(info2.PathAbs())
-	return n1 > n2
+	return cmp.Compare(b.contentsCount, a.contentsCount)
 }
 
-// sort by time (modified time by default) in decending order (newer first)
-type TimeSorter ItemSorter
-
-func (s TimeSorter) Len() int      { return len(s) }
-func (s TimeSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s TimeSorter) Less(i, j int) bool {
+// compareTime compares by time (modified time by default)
+// in decending order (newer first)
+// items without time are moved after others by sortItems, but they
+// are also put last here
+func compareTime(a *sortEntry, b *sortEntry) int {
 	// do NOT compare unix times (returned by _time.Unix)
 	// because of DST stuff, it's complicated
-	tm1 := s[i].Time
-	tm2 := s[j].Time
+	tm1 := a.time
+	tm2 := b.time
 	if tm1 == nil || tm2 == nil {
-		panic("time is nil")
-		// return false
+		switch {
+		case tm1 == tm2:
+			return 0
+		case tm1 == nil:
+			return 1
+		}
+		return -1
 	}
-	return tm1.After(*tm2)
+	return tm2.Compare(*tm1)
 }
 
-type ExtensionSorter ItemSorter
-
-func (s ExtensionSorter) Len() int      { return len(s) }
-func (s ExtensionSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s ExtensionSorter) Less(i, j int) bool {
-	return s[i].Ext() < s[j].Ext()
+func compareExtension(a *sortEntry, b *sortEntry) int {
+	return strings.Compare(a.Ext(), b.Ext())
 }
 
-// KindSorter tells `sort.Sort` how to sort by file extension
-type KindSorter ItemSorter
-
-func (s KindSorter) Len() int      { return len(s) }
-func (s KindSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-func (s KindSorter) Less(i, j int) bool {
-	var kindi, kindj string
-	if s[i].Basename() == "" {
-		kindi = "."
-	} else if s[i].Ext() == "" {
-		kindi = "0"
-	} else {
-		kindi = s[i].Ext()
+func itemKind(item *sortEntry) string {
+	if item.Basename() == "" {
+		return "."
 	}
-	if s[j].Basename() == "" {
-		kindj = "."
-	} else if s[j].Ext() == "" {
-		kindj = "0"
-	} else {
-		kindj = s[j].Ext()
+	if item.Ext() == "" {
+		return "0"
 	}
-	if kindi == kindj {
-		if kindi == "." {
-			return s[i].Ext() < s[j].Ext()
+	return item.Ext()
+}
+
+// compareKind compares by kind: dotfiles, then files without extension,
+// then by extension
+func compareKind(a *sortEntry, b *sortEntry) int {
+	kind1 := itemKind(a)
+	kind2 := itemKind(b)
+	if kind1 == kind2 {
+		if kind1 == "." {
+			return strings.Compare(a.Ext(), b.Ext())
 		}
-		return s[i].Basename() < s[j].Basename()
+		return strings.Compare(a.Basename(), b.Basename())
 	}
-	return kindi < kindj
+	return strings.Compare(kind1, kind2)
+}
+
+func compareInode(a *sortEntry, b *sortEntry) int {
+	return cmp.Compare(a.inode, b.inode)
+}
+
+// compareHardLinks compares by number of hard links in decending order
+func compareHardLinks(a *sortEntry, b *sortEntry) int {
+	return cmp.Compare(b.hardLinks, a.hardLinks)
 }
 
-type InodeSorter ItemSorter
+// compareMode compares numeric mode in decending order
+func compareMode(a *sortEntry, b *sortEntry) int {
+	return cmp.Compare(b.Mode(), a.Mode())
+}
 
-func (s InodeSorter) Len() int      { return len(s) }
-func (s InodeSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func compareNameLength(a *sortEntry, b *sortEntry) int {
+	return cmp.Compare(len(a.Name()), len(b.Name()))
+}
 
-func (s InodeSorter) Less(i, j int) bool {
-	inode1, _ := app.Platform.FileInode(s[i])
-	inode2, _ := app.Platform.FileInode(s[j])
-	return inode1 < inode2
+// compareVersion compares names, treating digit sequences as numbers
+// like `ls -v` (see filevercmp)
+func compareVersion(a *sortEntry, b *sortEntry) int {
+	return filevercmp(a.Name(), b.Name())
 }
 
-type HardLinksSorter ItemSorter
+// compareExpr compares values of --sort-expr
+func compareExpr(a *sortEntry, b *sortEntry) int {
+	return compareExprValues(a.exprValue, b.exprValue)
+}
 
-func (s HardLinksSorter) Len() int      { return len(s) }
-func (s HardLinksSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+// compareFinal is the last comparator, to make sorting deterministic
+// it compares (case-sensitive) names, then directories
+func compareFinal(a *sortEntry, b *sortEntry) int {
+	diff := strings.Compare(a.Name(), b.Name())
+	if diff != 0 {
+		return diff
+	}
+	return strings.Compare(a.Dir(), b.Dir())
+}
 
-func (s HardLinksSorter) Less(i, j int) bool {
-	n1, _ := app.Platform.NumberOfHardLinks(s[i])
-	n2, _ := app.Platform.NumberOfHardLinks(s[j])
-	return n1 > n2
+// MultiSorter sorts entries by a list of comparators, each comparator
+// is only used for entries that are equal by the previous ones
+// entries that are equal by all comparators keep their original order
+// (even if reverse is true), so it can be used with sort.Sort
+type MultiSorter struct {
+	entries     []*sortEntry
+	comparators []ItemComparator
+	reverse     bool
 }
 
-type ModeSorter ItemSorter
+func (s *MultiSorter) Len() int      { return len(s.entries) }
+func (s *MultiSorter) Swap(i, j int) { s.entries[i], s.entries[j] = s.entries[j], s.entries[i] }
 
-func (s ModeSorter) Len() int      { return len(s) }
-func (s ModeSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s *MultiSorter) Less(i, j int) bool {
+	a := s.entries[i]
+	b := s.entries[j]
+	for _, compare := range s.comparators {
+		diff := compare(a, b)
+		if diff != 0 {
+			return (diff < 0) != s.reverse
+		}
+	}
+	return a.index < b.index
+}
 
-func (s ModeSorter) Less(i, j int) bool {
-	return s[i].Mode() > s[j].Mode()
+// InodeGroupSorter keeps items that share (dev, inode) next to each other,
+// at the position of the first one, keys are set by groupHardLinks
+type InodeGroupSorter struct {
+	items []*DisplayItem
+	keys  []int
 }
 
-type NameLengthSorter ItemSorter
+func (s *InodeGroupSorter) Len() int { return len(s.items) }
 
-func (s NameLengthSorter) Len() int      { return len(s) }
-func (s NameLengthSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s *InodeGroupSorter) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
 
-func (s NameLengthSorter) Less(i, j int) bool {
-	return len(s[i].Name()) < len(s[j].Name())
+func (s *InodeGroupSorter) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
 }
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/iface"
+)
+
+const benchSortItemCount = 100_000
+
+// countingFileSystem counts calls of CountDirContents, which needs
+// a few syscalls for each call on local file system
+type countingFileSystem struct {
+	iface.FileSystem
+	calls atomic.Int64
+}
+
+func (fs *countingFileSystem) CountDirContents(name string) (int, error) {
+	fs.calls.Add(1)
+	return fs.FileSystem.CountDirContents(name)
+}
+
+// setupSortBenchmark creates a directory with benchSortItemCount
+// sub-directories (each with 0 to 2 files), and returns them as items
+func setupSortBenchmark(b *testing.B) (*countingFileSystem, []*DisplayItem) {
+	app = NewApplication()
+	b.Cleanup(func() {
+		app = nil
+	})
+	countingFS := &countingFileSystem{FileSystem: app.FileSystem}
+	app.FileSystem = countingFS
+	parent := b.TempDir()
+	items := make([]*DisplayItem, benchSortItemCount)
+	for index := range items {
+		name := fmt.Sprintf("dir%d", (index*7919)%benchSortItemCount)
+		dirPath := filepath.Join(parent, name)
+		if err := os.Mkdir(dirPath, 0o755); err != nil {
+			b.Fatal(err)
+		}
+		for fileIndex := 0; fileIndex < index%3; fileIndex++ {
+			filePath := filepath.Join(dirPath, strconv.Itoa(fileIndex))
+			if err := os.WriteFile(filePath, nil, 0o644); err != nil {
+				b.Fatal(err)
+			}
+		}
+		stat, err := os.Lstat(dirPath)
+		if err != nil {
+			b.Fatal(err)
+		}
+		items[index] = &DisplayItem{
+			FileInfo: &FileInfoImp{
+				FileInfo: stat,
+				basename: name,
+				dir:      parent,
+				curDir:   parent,
+			},
+		}
+	}
+	return countingFS, items
+}
+
+// dirCountInLessSorter is how directories were sorted by size before
+// precomputing sort keys (calling CountDirContents in Less), for comparison
+type dirCountInLessSorter []*DisplayItem
+
+func (s dirCountInLessSorter) Len() int      { return len(s) }
+func (s dirCountInLessSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s dirCountInLessSorter) Less(i, j int) bool {
+	n1, _ := app.FileSystem.CountDirContents(s[i].PathAbs())
+	n2, _ := app.FileSystem.CountDirContents(s[j].PathAbs())
+	return n1 > n2
+}
+
+func BenchmarkSortDirsBySize(b *testing.B) {
+	countingFS, items := setupSortBenchmark(b)
+	work := make([]*DisplayItem, len(items))
+	run := func(name string, sortFunc func([]*DisplayItem)) {
+		b.Run(name, func(b *testing.B) {
+			countingFS.calls.Store(0)
+			for i := 0; i < b.N; i++ {
+				copy(work, items)
+				sortFunc(work)
+			}
+			b.ReportMetric(float64(countingFS.calls.Load())/float64(b.N), "calls/op")
+		})
+	}
+	keys := []sortKey{{name: c.S_SIZE}}
+	run("Precomputed", func(work []*DisplayItem) {
+		sortDirs(work, keys, false)
+	})
+	run("InLess", func(work []*DisplayItem) {
+		sort.Sort(dirCountInLessSorter(work))
+	})
+}
+
+func BenchmarkSortByName(b *testing.B) {
+	app = NewApplication()
+	b.Cleanup(func() {
+		app = nil
+	})
+	items := make([]*DisplayItem, benchSortItemCount)
+	for index := range items {
+		name := fmt.Sprintf("file%d.txt", (index*7919)%benchSortItemCount)
+		items[index] = &DisplayItem{
+			FileInfo: &FileInfoImp{
+				FileInfo: &FileInfoLow{name: name},
+				basename: name[:len(name)-4],
+				ext:      ".txt",
+			},
+		}
+	}
+	keys := []sortKey{{name: c.S_NAME}}
+	work := make([]*DisplayItem, len(items))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(work, items)
+		sortFiles(work, keys, false)
+	}
+}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/ilius/ls-go/common"
+)
+
+// minimum number of items to compute sort keys in parallel
+const parallelSortMinItems = 1000
+
+// sortEntryKeys is a set of keys to be precomputed for sorting
+type sortEntryKeys uint8
+
+const (
+	keyLowerName sortEntryKeys = 1 << iota
+	keyLowerBasename
+	keyContentsCount
+	keyInode
+	keyHardLinks
+	keyExpr
+	keyTime
+)
+
+// sortEntry holds an item and its sort keys, which are computed once
+// before sorting, so comparators do not call any function that may
+// need a syscall or may fail
+type sortEntry struct {
+	*DisplayItem
+
+	index int // original index in items
+	isDir bool
+	size  int64
+
+	lowerName     string
+	lowerBasename string
+	contentsCount int // only for directories
+	inode         uint64
+	hardLinks     uint64
+	exprValue     any
+	time          *time.Time // nil if not available
+
+	err error
+}
+
+func (e *sortEntry) setError(err error) {
+	if err != nil && e.err == nil {
+		e.err = err
+	}
+}
+
+func (e *sortEntry) init(item *DisplayItem, index int, keys sortEntryKeys) {
+	e.DisplayItem = item
+	e.index = index
+	e.isDir = item.IsDir()
+	e.size = item.Size()
+	if keys&keyLowerName != 0 {
+		e.lowerName = strings.ToLower(item.Name())
+	}
+	if keys&keyLowerBasename != 0 {
+		e.lowerBasename = strings.ToLower(item.Basename())
+	}
+	if keys&keyContentsCount != 0 && e.isDir {
+		count, err := app.FileSystem.CountDirContents(item.PathAbs())
+		e.contentsCount = count
+		e.setError(err)
+	}
+	if keys&keyInode != 0 {
+		inode, err := item.Inode()
+		e.inode = inode
+		e.setError(err)
+	}
+	if keys&keyHardLinks != 0 {
+		hardLinks, err := item.NumberOfHardLinks()
+		e.hardLinks = hardLinks
+		e.setError(err)
+	}
+	if keys&keyTime != 0 {
+		e.time = item.Time
+		if e.time == nil {
+			e.setError(fmt.Errorf("%s is not available", app.PrimaryTimeColName))
+		}
+	}
+	if keys&keyExpr != 0 {
+		e.initExpr()
+	}
+}
+
+// initExpr evaluates --sort-expr, which may fill caches of FileInfo
+// and platform, or add errors, so it must not be called in parallel
+func (e *sortEntry) initExpr() {
+	value, err := app.sortExprGetter.evaluateExpr(e.FileInfo)
+	if tm, ok := value.(*time.Time); ok && tm != nil {
+		value = *tm
+	}
+	e.exprValue = value
+	e.setError(err)
+}
+
+// newSortEntries computes sort keys of items, in parallel if there are
+// many items (except --sort-expr), and adds an error for each item that failed
+func newSortEntries(items []*DisplayItem, keys sortEntryKeys) []*sortEntry {
+	block := make([]sortEntry, len(items))
+	entries := make([]*sortEntry, len(items))
+	for index := range block {
+		entries[index] = &block[index]
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if len(items) < parallelSortMinItems || workers < 2 {
+		for index, item := range items {
+			entries[index].init(item, index, keys)
+		}
+	} else {
+		chunkSize := (len(items) + workers - 1) / workers
+		parallelKeys := keys &^ keyExpr
+		var wg sync.WaitGroup
+		for start := 0; start < len(items); start += chunkSize {
+			end := min(start+chunkSize, len(items))
+			wg.Add(1)
+			go func(start int, end int) {
+				defer wg.Done()
+				for index := start; index < end; index++ {
+					entries[index].init(items[index], index, parallelKeys)
+				}
+			}(start, end)
+		}
+		wg.Wait()
+		if keys&keyExpr != 0 {
+			for _, e := range entries {
+				e.initExpr()
+			}
+		}
+	}
+	for _, e := range entries {
+		if e.err != nil {
+			app.AddError(&c.FileError{
+				Path: e.PathDisplay(),
+				Msg:  e.err.Error(),
+			})
+		}
+	}
+	return entries
+}
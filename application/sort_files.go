@@ -6,154 +6,128 @@ import (
 	c "github.com/ilius/ls-go/common"
 )
 
-func sortFiles(files []*DisplayItem, col string, reverse bool) {
-	switch col {
-	case c.S_NONE:
-		break // do not sort
-	case c.S_NAME:
-		sortByName(files, reverse)
-	case c.S_BASENAME:
-		sortByBasename(files, reverse)
-	case c.S_SIZE:
-		sortBySize(files, reverse)
-	case c.S_FILESIZE:
-		sortByFileSize(files, reverse)
-	case c.S_TIME:
-		sortByTime(files, reverse)
-	// case S_VERSION:
-	case c.S_EXTENSION:
-		sortByExtension(files, reverse)
-	case c.S_KIND:
-		sortByKind(files, reverse)
-	case c.S_INODE:
-		sortByInode(files, reverse)
-	case c.S_LINKS:
-		sortByLinks(files, reverse)
-	case c.S_MODE:
-		sortByMode(files, reverse)
-	case c.S_NAME_LEN:
-		sortByNameLen(files, reverse)
-	default: // default is (basename, extension)
-		sortDefault(files, reverse)
-	}
+// sortColumn is a comparator with the keys it needs to be precomputed
+type sortColumn struct {
+	compare ItemComparator
+	keys    sortEntryKeys
 }
 
-func sortByName(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(NameSorter(files)))
-	} else {
-		sort.Sort(NameSorter(files))
-	}
+var sortColumns = map[string]sortColumn{
+	c.S_NAME:      {compareName, keyLowerName},
+	c.S_BASENAME:  {compareBasename, keyLowerBasename},
+	c.S_SIZE:      {compareSize, keyContentsCount},
+	c.S_FILESIZE:  {compareFileSize, keyLowerName},
+	c.S_TIME:      {compareTime, keyTime},
+	c.S_VERSION:   {compareVersion, 0},
+	c.S_EXTENSION: {compareExtension, 0},
+	c.S_KIND:      {compareKind, 0},
+	c.S_INODE:     {compareInode, keyInode},
+	c.S_LINKS:     {compareHardLinks, keyHardLinks},
+	c.S_MODE:      {compareMode, 0},
+	c.S_NAME_LEN:  {compareNameLength, 0},
+	c.S_EXPR:      {compareExpr, keyExpr},
 }
 
-func sortByBasename(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(BasenameSorter(files)))
-	} else {
-		sort.Sort(BasenameSorter(files))
-	}
+// columns that are used instead of sortColumns
+// for directories that are pinned (shown before files)
+var dirSortColumns = map[string]sortColumn{
+	c.S_SIZE:     {compareDirContentsCount, keyContentsCount},
+	c.S_FILESIZE: {compareDefault, keyLowerBasename},
 }
 
-func sortBySize(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(SizeSorter(files)))
-	} else {
-		sort.Sort(SizeSorter(files))
+// makeComparators returns the list of comparators for given sort keys
+// followed by the default comparator and compareFinal as tie-breakers
+// and the keys that need to be computed for them
+func makeComparators(keys []sortKey, dirs bool) ([]ItemComparator, sortEntryKeys) {
+	comparators := []ItemComparator{}
+	entryKeys := keyLowerBasename // for compareDefault
+	for _, key := range keys {
+		if key.name == c.S_INODE_GROUP {
+			continue
+		}
+		column := sortColumns[key.name]
+		if dirColumn, ok := dirSortColumns[key.name]; ok && dirs {
+			column = dirColumn
+		}
+		compare := column.compare
+		if key.reverse {
+			compare = reverseComparator(compare)
+		}
+		comparators = append(comparators, compare)
+		entryKeys |= column.keys
 	}
+	return append(comparators, compareDefault, compareFinal), entryKeys
 }
 
-func sortByFileSize(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(FileSizeSorter(files)))
-	} else {
-		sort.Sort(FileSizeSorter(files))
-	}
+func sortFiles(files []*DisplayItem, keys []sortKey, reverse bool) {
+	sortItems(files, keys, reverse, false)
 }
 
-func sortByTime(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(TimeSorter(files)))
-	} else {
-		sort.Sort(TimeSorter(files))
-	}
+func sortDirs(dirs []*DisplayItem, keys []sortKey, reverse bool) {
+	sortItems(dirs, keys, reverse, true)
 }
 
-func sortByExtension(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(ExtensionSorter(files)))
-	} else {
-		sort.Sort(ExtensionSorter(files))
+func sortItems(items []*DisplayItem, keys []sortKey, reverse bool, dirs bool) {
+	if len(items) == 0 || hasSortKey(keys, c.S_NONE) {
+		return
 	}
-}
-
-func sortByKind(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(KindSorter(files)))
-	} else {
-		sort.Sort(KindSorter(files))
+	comparators, entryKeys := makeComparators(keys, dirs)
+	entries := newSortEntries(items, entryKeys)
+	// items without time are put last, even if order is reversed
+	var noTime []*sortEntry
+	if entryKeys&keyTime != 0 {
+		entries, noTime = splitNoTime(entries)
 	}
-}
-
-func sortByInode(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(InodeSorter(files)))
-	} else {
-		sort.Sort(InodeSorter(files))
+	for _, part := range [][]*sortEntry{entries, noTime} {
+		sort.Sort(&MultiSorter{
+			entries:     part,
+			comparators: comparators,
+			reverse:     reverse,
+		})
 	}
-}
-
-func sortByLinks(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(HardLinksSorter(files)))
-	} else {
-		sort.Sort(HardLinksSorter(files))
-	}
-}
-
-func sortByMode(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(ModeSorter(files)))
-	} else {
-		sort.Sort(ModeSorter(files))
+	entries = append(entries, noTime...)
+	for index, e := range entries {
+		items[index] = e.DisplayItem
 	}
-}
-
-func sortByNameLen(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(NameLengthSorter(files)))
-	} else {
-		sort.Sort(NameLengthSorter(files))
+	if hasSortKey(keys, c.S_INODE_GROUP) {
+		groupHardLinks(items)
 	}
 }
 
-// default is (basename, extension)
-func sortDefault(files []*DisplayItem, reverse bool) {
-	if reverse {
-		sort.Sort(sort.Reverse(DefaultSorter(files)))
-	} else {
-		sort.Sort(DefaultSorter(files))
+// splitNoTime splits entries into the ones with time and the ones without
+// time, keeping their order
+func splitNoTime(entries []*sortEntry) ([]*sortEntry, []*sortEntry) {
+	withTime := make([]*sortEntry, 0, len(entries))
+	noTime := []*sortEntry{}
+	for _, e := range entries {
+		if e.time == nil {
+			noTime = append(noTime, e)
+			continue
+		}
+		withTime = append(withTime, e)
 	}
+	return withTime, noTime
 }
 
-func sortDirs(dirs []*DisplayItem, col string, reverse bool) {
-	if len(dirs) == 0 {
-		return
-	}
-	switch col {
-	case c.S_SIZE:
-		if reverse {
-			sort.Sort(sort.Reverse(DirContentsCountSorter(dirs)))
-			return
+// groupHardLinks moves hard links to the same file next to the first one
+func groupHardLinks(files []*DisplayItem) {
+	first := map[devInode]int{}
+	keys := make([]int, len(files))
+	for index, item := range files {
+		keys[index] = index
+		key, ok := fileDevInode(item.FileInfo)
+		if !ok {
+			continue
 		}
-		sort.Sort(DirContentsCountSorter(dirs))
-		return
-	case c.S_FILESIZE:
-		if reverse {
-			sort.Sort(sort.Reverse(DefaultSorter(dirs)))
-			return
+		firstIndex, seen := first[key]
+		if !seen {
+			first[key] = index
+			continue
 		}
-		sort.Sort(DefaultSorter(dirs))
-		return
+		keys[index] = firstIndex
 	}
-	sortFiles(dirs, col, reverse)
+	sort.Stable(&InodeGroupSorter{
+		items: files,
+		keys:  keys,
+	})
 }
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+	c "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lsplatform"
+)
+
+func TestSortItemsNoTime(t *testing.T) {
+	is := is.New(t)
+	app = NewApplication()
+	app.PrimaryTimeColName = c.C_CTime
+	defer func() {
+		app = nil
+	}()
+	now := time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)
+	item := func(name string, tm *time.Time) *DisplayItem {
+		return &DisplayItem{
+			FileInfo: &FileInfoImp{
+				FileInfo: &FileInfoLow{name: name},
+				basename: name,
+			},
+			Time: tm,
+		}
+	}
+	names := func(items []*DisplayItem) []string {
+		result := []string{}
+		for _, item := range items {
+			result = append(result, item.Name())
+		}
+		return result
+	}
+	older := now.Add(-time.Hour)
+	items := []*DisplayItem{
+		item("a", nil),
+		item("b", &older),
+		item("c", nil),
+		item("d", &now),
+	}
+	keys := []sortKey{{name: c.S_TIME}}
+	sortFiles(items, keys, false)
+	is.Equal(names(items), []string{"d", "b", "a", "c"})
+	is.Equal(len(app.errors), 2)
+
+	// still last if reversed
+	sortFiles(items, keys, true)
+	is.Equal(names(items), []string{"b", "d", "c", "a"})
+}
+
+// TestSortItemsExprParallel should be run with -race: --sort-expr
+// may fill caches of FileInfo and platform, and may add errors
+func TestSortItemsExprParallel(t *testing.T) {
+	is := is.New(t)
+	app = NewApplication()
+	lastGetOwnerAndGroup := getOwnerAndGroup
+	lastProcs := runtime.GOMAXPROCS(8)
+	defer func() {
+		app = nil
+		getOwnerAndGroup = lastGetOwnerAndGroup
+		runtime.GOMAXPROCS(lastProcs)
+	}()
+	getOwnerAndGroup = func(info lsplatform.FileInfo) (*lsplatform.OwnerGroup, error) {
+		return nil, fmt.Errorf("no owner for %s", info.Name())
+	}
+	getter, err := NewExprGetter(false, "group")
+	is.NotErr(err)
+	app.sortExprGetter = getter
+	count := parallelSortMinItems * 3
+	items := make([]*DisplayItem, count)
+	for index := range items {
+		name := fmt.Sprintf("f%d", index)
+		items[index] = &DisplayItem{
+			FileInfo: &FileInfoImp{
+				FileInfo: &FileInfoLow{name: name},
+				basename: name,
+			},
+		}
+	}
+	sortFiles(items, []sortKey{{name: c.S_EXPR}}, false)
+	is.Equal(len(items), count)
+	is.Equal(len(app.errors), count)
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	c "github.com/ilius/ls-go/common"
+)
+
+// sortKey is one item of comma-separated value of --sort
+type sortKey struct {
+	name    string
+	reverse bool // "-" prefix
+}
+
+var sortKeyAliases = map[string]string{
+	"ext": c.S_EXTENSION,
+}
+
+func hasSortKey(keys []sortKey, name string) bool {
+	for _, key := range keys {
+		if key.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSortKeys parses value of --sort, like "ext,-size,name"
+// if hasExpr is true (--sort-expr is passed) and "expr" is not
+// in the list, it is used as the first key
+func parseSortKeys(value string, hasExpr bool) ([]sortKey, error) {
+	keys := []sortKey{}
+	if value != "" {
+		for _, part := range strings.Split(value, ",") {
+			key := sortKey{name: strings.TrimSpace(part)}
+			if strings.HasPrefix(key.name, "-") {
+				key.name = key.name[1:]
+				key.reverse = true
+			}
+			if alias, ok := sortKeyAliases[key.name]; ok {
+				key.name = alias
+			}
+			switch key.name {
+			case "":
+				return nil, fmt.Errorf("invalid --sort=%#v: empty column", value)
+			case c.S_NONE:
+				if value != c.S_NONE {
+					return nil, fmt.Errorf("invalid --sort=%#v: %#v can not be combined", value, c.S_NONE)
+				}
+			case c.S_INODE_GROUP:
+				if key.reverse {
+					return nil, fmt.Errorf("invalid --sort=%#v: %#v can not be reversed", value, c.S_INODE_GROUP)
+				}
+			case c.S_EXPR:
+				if !hasExpr {
+					return nil, fmt.Errorf("invalid --sort=%#v: %#v requires --sort-expr", value, c.S_EXPR)
+				}
+			default:
+				if _, ok := sortColumns[key.name]; !ok {
+					return nil, fmt.Errorf("invalid --sort=%#v: unknown column %#v", value, key.name)
+				}
+			}
+			if hasSortKey(keys, key.name) {
+				return nil, fmt.Errorf("invalid --sort=%#v: duplicate column %#v", value, key.name)
+			}
+			keys = append(keys, key)
+		}
+	}
+	if hasExpr && !hasSortKey(keys, c.S_EXPR) {
+		if hasSortKey(keys, c.S_NONE) {
+			return nil, fmt.Errorf("--sort-expr can not be used with --sort=%s", c.S_NONE)
+		}
+		keys = append([]sortKey{{name: c.S_EXPR}}, keys...)
+	}
+	return keys, nil
+}
+
+// compareExprValues compares values of same type, or of any numeric types
+// other values are compared by their string representation
+func compareExprValues(a any, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0
+		case a == nil:
+			return 1
+		}
+		return -1
+	}
+	switch at := a.(type) {
+	case string:
+		if bt, ok := b.(string); ok {
+			return strings.Compare(at, bt)
+		}
+	case bool:
+		if bt, ok := b.(bool); ok {
+			return compareBool(at, bt)
+		}
+	case time.Time:
+		if bt, ok := b.(time.Time); ok {
+			return at.Compare(bt)
+		}
+	}
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+	switch {
+	case av.CanInt() && bv.CanInt():
+		return cmp.Compare(av.Int(), bv.Int())
+	case av.CanUint() && bv.CanUint():
+		return cmp.Compare(av.Uint(), bv.Uint())
+	case isNumber(av) && isNumber(bv):
+		return cmp.Compare(toFloat(av), toFloat(bv))
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+func compareBool(a bool, b bool) int {
+	if a == b {
+		return 0
+	}
+	if b {
+		return -1
+	}
+	return 1
+}
+
+func isNumber(v reflect.Value) bool {
+	return v.CanInt() || v.CanUint() || v.CanFloat()
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	is := is.New(t)
+	test := func(value string, hasExpr bool, expected []sortKey) {
+		keys, err := parseSortKeys(value, hasExpr)
+		is.AddMsg("value=%#v", value).NotErr(err)
+		is.AddMsg("value=%#v", value).Equal(keys, expected)
+	}
+	testErr := func(value string, hasExpr bool, errStr string) {
+		_, err := parseSortKeys(value, hasExpr)
+		is.AddMsg("value=%#v", value).ErrMsg(err, errStr)
+	}
+	test("", false, []sortKey{})
+	test("size", false, []sortKey{{name: "size"}})
+	test("ext,-size,name", false, []sortKey{
+		{name: "extension"},
+		{name: "size", reverse: true},
+		{name: "name"},
+	})
+	test("time", true, []sortKey{
+		{name: "expr"},
+		{name: "time"},
+	})
+	test("time,-expr", true, []sortKey{
+		{name: "time"},
+		{name: "expr", reverse: true},
+	})
+	test("none", false, []sortKey{{name: "none"}})
+	testErr("foo", false, `invalid --sort="foo": unknown column "foo"`)
+	testErr("name,", false, `invalid --sort="name,": empty column`)
+	testErr("name,none", false, `invalid --sort="name,none": "none" can not be combined`)
+	testErr("size,-size", false, `invalid --sort="size,-size": duplicate column "size"`)
+	testErr("-inode-group", false, `invalid --sort="-inode-group": "inode-group" can not be reversed`)
+	testErr("expr", false, `invalid --sort="expr": "expr" requires --sort-expr`)
+}
+
+func TestCompareExprValues(t *testing.T) {
+	is := is.New(t)
+	is.Equal(compareExprValues(1, 2), -1)
+	is.Equal(compareExprValues(int64(3), 2), 1)
+	is.Equal(compareExprValues(uint64(3), 3.5), -1)
+	is.Equal(compareExprValues("b", "a"), 1)
+	is.Equal(compareExprValues(false, true), -1)
+	is.Equal(compareExprValues(nil, 1), 1)
+	is.Equal(compareExprValues(nil, nil), 0)
+}
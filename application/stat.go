@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/ilius/ls-go/common"
 )
 
 func printStats(colorsEnable bool, numFiles int, numDirs int) {
@@ -39,3 +41,22 @@ func printStatsNoColor(numFiles int, numDirs int) {
 	}
 	fmt.Fprintln(stdout, strings.Join(statStrings, " "))
 }
+
+// printLinkStats prints number of listed hard links to an already listed file
+// and total size that they would take if they were not hard links
+func printLinkStats(colorsEnable bool, numLinks int, saved uint64) {
+	c := colors.Stats
+	statStrings := []string{
+		strconv.FormatInt(int64(numLinks), 10),
+		"hard links",
+		common.FormatSizeShort(saved),
+		"saved",
+	}
+	if colorsEnable {
+		statStrings[0] = app.Colorize(statStrings[0], c.Number)
+		statStrings[1] = app.Colorize(statStrings[1], c.Text)
+		statStrings[2] = app.Colorize(statStrings[2], c.Number)
+		statStrings[3] = app.Colorize(statStrings[3], c.Text)
+	}
+	fmt.Fprintln(stdout, strings.Join(statStrings, " "))
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	c "github.com/ilius/ls-go/common"
+)
+
+// newFolderSummary returns summary of listed items of a directory, if it
+// is needed for "total" line or --summary, otherwise returns nil
+func (app *Application) newFolderSummary(path string, itemLists ...[]*DisplayItem) *c.FolderSummary {
+	if path == "" || !(app.showTotal || *args.Summary) {
+		return nil
+	}
+	summary := &c.FolderSummary{Path: path}
+	kiloBlocks := uint64(0)
+	for _, items := range itemLists {
+		for _, item := range items {
+			if item.IsDir() {
+				summary.Dirs++
+			} else {
+				summary.Files++
+				summary.Size += uint64(item.Size())
+			}
+			kiloBlocks += uint64(item.Blocks())
+			mtime := item.ModTime()
+			if summary.Newest == nil || mtime.After(*summary.Newest) {
+				summary.Newest = &mtime
+			}
+			if summary.Oldest == nil || mtime.Before(*summary.Oldest) {
+				summary.Oldest = &mtime
+			}
+		}
+	}
+	// round up, like `ls`
+	unit := app.blocksFormat.Unit()
+	summary.Blocks = (kiloBlocks*1024 + unit - 1) / unit
+	return summary
+}
+
+func (app *Application) printTotal(summary *c.FolderSummary) {
+	if summary == nil || !app.showTotal {
+		return
+	}
+	total, _ := app.blocksFormat.Format(summary.Blocks * app.blocksFormat.Unit())
+	app.TotalBlocks(stdout, total)
+}
+
+func (app *Application) printSummary(summary *c.FolderSummary) {
+	if summary == nil || !*args.Summary {
+		return
+	}
+	app.FolderSummary(stdout, summary)
+}
@@ -0,0 +1,349 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	col "github.com/ilius/ls-go/lscolors"
+	"github.com/ilius/ls-go/lsconfig"
+)
+
+// maxThemeDepth is the maximum number of nested "base" themes
+const maxThemeDepth = 10
+
+// builtinThemes maps theme names to functions that apply the theme
+// over the default colors (after light background colors, if detected)
+var builtinThemes = map[string]func(c *col.Colors){
+	"default":         func(c *col.Colors) {},
+	"solarized":       solarizedTheme,
+	"high-contrast":   highContrastTheme,
+	"colorblind-safe": colorblindSafeTheme,
+	"monochrome-bold": monochromeBoldTheme,
+}
+
+func builtinThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// themeFile is the format of a theme file
+// Colors has the same format as LSGO_COLORS, and is merged over Base
+// (a built-in theme or another theme file, "default" if empty)
+type themeFile struct {
+	Base   string          `json:"base"`
+	Colors json.RawMessage `json:"colors"`
+}
+
+// applyTheme applies a built-in theme or a theme file (by name or path)
+// to colors
+func applyTheme(c *col.Colors, name string) error {
+	return applyThemeDepth(c, name, 0)
+}
+
+func applyThemeDepth(c *col.Colors, name string, depth int) error {
+	apply := builtinThemes[name]
+	if apply != nil {
+		apply(c)
+		return nil
+	}
+	if depth >= maxThemeDepth {
+		return fmt.Errorf("theme %#v: too many nested base themes", name)
+	}
+	path := lsconfig.ThemePath(name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf(
+				"theme %#v not found, built-in themes: %v, or create %s",
+				name, builtinThemeNames(), path,
+			)
+		}
+		return err
+	}
+	file := themeFile{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if file.Base != "" {
+		err = applyThemeDepth(c, file.Base, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	if len(file.Colors) == 0 {
+		return nil
+	}
+	themeColors, err := col.ParseColors(file.Colors)
+	if err != nil {
+		return fmt.Errorf("%s: colors: %w", path, err)
+	}
+	c.Merge(themeColors)
+	return nil
+}
+
+func hexPalette(hexList ...string) []col.RGB {
+	palette := make([]col.RGB, len(hexList))
+	for i, hex := range hexList {
+		rgb, err := col.ParseHex(hex)
+		if err != nil {
+			panic(err)
+		}
+		palette[i] = *rgb
+	}
+	return palette
+}
+
+// mapToPalette replaces every color with the nearest one in palette
+func mapToPalette(c *col.Colors, palette []col.RGB) {
+	c.MapStyles(func(style *col.Style) *col.Style {
+		return style.ToPalette(palette)
+	})
+}
+
+// lightDark returns first value for dark background and second value
+// for light background
+func lightDark(dark string, light string) string {
+	if col.LightBackground {
+		return light
+	}
+	return dark
+}
+
+func boldRGB(hex string) *col.Style {
+	return col.RGBFg(hex).SetBold()
+}
+
+func solarizedTheme(c *col.Colors) {
+	const (
+		base03  = "#002b36"
+		base02  = "#073642"
+		base01  = "#586e75"
+		base00  = "#657b83"
+		base0   = "#839496"
+		base1   = "#93a1a1"
+		base2   = "#eee8d5"
+		base3   = "#fdf6e3"
+		yellow  = "#b58900"
+		orange  = "#cb4b16"
+		red     = "#dc322f"
+		magenta = "#d33682"
+		violet  = "#6c71c4"
+		blue    = "#268bd2"
+		cyan    = "#2aa198"
+		green   = "#859900"
+	)
+	mapToPalette(c, hexPalette(
+		base03, base02, base01, base00, base0, base1, base2, base3,
+		yellow, orange, red, magenta, violet, blue, cyan, green,
+	))
+	body := lightDark(base0, base00)
+	emphasis := lightDark(base1, base01)
+	secondary := lightDark(base01, base1)
+	highlight := lightDark(base02, base2)
+	c.Merge(&col.Colors{
+		File: col.LightDarkMap{
+			// main and accent colors are swapped for light background
+			col.DEFAULT: {Light: col.RGBFg(base1), Dark: col.RGBFg(base01)},
+		},
+		Dir: col.DirColors{
+			Name:       boldRGB(blue),
+			Ext:        col.RGBFg(cyan),
+			HiddenName: boldRGB(violet),
+			HiddenExt:  col.RGBFg(cyan),
+		},
+		Link: col.LinkColors{
+			Name:    boldRGB(cyan),
+			NameDir: boldRGB(blue).SetBgHex(highlight),
+			Arrow:   col.RGBFg(secondary),
+			Path:    col.RGBFg(violet),
+			Broken:  col.RGBFg(red),
+		},
+		Size: col.StyleMap{
+			"B": col.RGBFg(green),
+			"K": col.RGBFg(cyan),
+			"M": col.RGBFg(blue),
+			"G": col.RGBFg(violet),
+			"T": col.RGBFg(magenta),
+		},
+		Time: col.TimeColors{
+			Year:        col.RGBFg(yellow),
+			Number:      col.RGBFg(body),
+			NumberColon: col.RGBFg(emphasis),
+			NumberSlash: col.RGBFg(secondary),
+			Word:        col.RGBFg(blue),
+		},
+		Tabular: &col.TabularColors{
+			FolderHeader: col.FolderHeaderColors{
+				Arrow:      col.RGBFg(yellow),
+				Main:       col.RGBFg(yellow).SetBgHex(highlight),
+				Slash:      col.RGBFg(secondary),
+				LastFolder: boldRGB(orange),
+				Error:      col.RGBFg(base3).SetBgHex(red),
+				Space:      col.RGBFg(secondary),
+			},
+			GroupHeader: col.GroupHeaderColors{
+				Arrow:   col.RGBFg(yellow),
+				Label:   boldRGB(orange),
+				Summary: col.RGBFg(secondary),
+			},
+			TableHeader: col.RGBFg(blue),
+			Summary:     col.RGBFg(secondary),
+		},
+		Html: &col.HtmlColors{
+			Default: col.RGBFg(body).SetBgHex(lightDark(base03, base3)),
+		},
+		Device: boldRGB(yellow),
+		Socket: boldRGB(magenta),
+		Pipe:   boldRGB(orange),
+		Stats: col.StatsColors{
+			Text:   col.RGBFg(secondary),
+			Number: col.RGBFg(blue),
+			MS:     col.RGBFg(cyan),
+		},
+	})
+}
+
+func highContrastTheme(c *col.Colors) {
+	palette := hexPalette(
+		"#ffffff", "#000000", "#ff5f5f", "#5fff5f", "#ffff5f",
+		"#5fafff", "#ff5fff", "#5fffff",
+	)
+	fg, cyan := "#ffffff", "#5fffff"
+	if col.LightBackground {
+		palette = hexPalette(
+			"#000000", "#ffffff", "#af0000", "#005f00", "#875f00",
+			"#0000af", "#870087", "#005f5f",
+		)
+		fg, cyan = "#000000", "#005f5f"
+	}
+	// no dimmed accent colors
+	for key, ld := range c.File {
+		main, _ := ld.Get()
+		c.File[key] = col.LightDark{Light: main, Dark: main}
+	}
+	mapToPalette(c, palette)
+	c.MapStyles(func(style *col.Style) *col.Style {
+		result := *style
+		result.Dim = false
+		result.Bold = style.FgColor() != nil || style.Bold
+		return &result
+	})
+	c.Merge(&col.Colors{
+		File: col.LightDarkMap{
+			col.DEFAULT: {Light: boldRGB(fg), Dark: boldRGB(fg)},
+		},
+		Dir: col.DirColors{
+			Name:       boldRGB("#ffffff").SetBgHex("#0000d7"),
+			HiddenName: boldRGB("#ffffff").SetBgHex("#5f00d7"),
+		},
+		Link: col.LinkColors{
+			Name:    boldRGB(cyan),
+			NameDir: boldRGB("#000000").SetBgHex("#00d7d7"),
+			Broken:  boldRGB("#ffffff").SetBgHex("#d70000"),
+		},
+		Device: &col.Style{Bold: true, Reverse: true},
+		Socket: &col.Style{Bold: true, Reverse: true},
+		Pipe:   &col.Style{Bold: true, Underline: true},
+	})
+}
+
+// colorblindSafeTheme uses the Okabe-Ito palette, which is distinguishable
+// with all common types of color blindness
+func colorblindSafeTheme(c *col.Colors) {
+	const (
+		orange    = "#e69f00"
+		skyBlue   = "#56b4e9"
+		green     = "#009e73"
+		yellow    = "#f0e442"
+		blue      = "#0072b2"
+		vermilion = "#d55e00"
+		purple    = "#cc79a7"
+	)
+	mapToPalette(c, hexPalette(
+		orange, skyBlue, green, yellow, blue, vermilion, purple,
+		"#000000", "#555555", "#999999", "#dddddd", "#ffffff",
+	))
+	broken := col.RGBFg(vermilion)
+	broken.Strike = true
+	c.Merge(&col.Colors{
+		Dir: col.DirColors{
+			Name:       boldRGB("#ffffff").SetBgHex(blue),
+			Ext:        col.RGBFg(skyBlue),
+			HiddenName: boldRGB("#ffffff").SetBgHex(purple),
+			HiddenExt:  col.RGBFg(skyBlue),
+		},
+		Link: col.LinkColors{
+			Name:    boldRGB(skyBlue),
+			NameDir: boldRGB("#000000").SetBgHex(skyBlue),
+			Path:    col.RGBFg(purple),
+			// not only by color
+			Broken: broken,
+		},
+		// increasing size also increases lightness contrast
+		Size: col.StyleMap{
+			"B": col.RGBFg(skyBlue),
+			"K": col.RGBFg(green),
+			"M": col.RGBFg(yellow),
+			"G": col.RGBFg(orange),
+			"T": col.RGBFg(vermilion),
+		},
+		Exec:   boldRGB(green),
+		Device: boldRGB(yellow),
+		Socket: boldRGB(purple),
+		Pipe:   boldRGB(orange),
+	})
+}
+
+// monochromeBoldTheme uses no colors, only text attributes
+func monochromeBoldTheme(c *col.Colors) {
+	c.MapStyles(func(style *col.Style) *col.Style {
+		return &col.Style{Bold: style.Bold}
+	})
+	plain := &col.Style{}
+	dim := &col.Style{Dim: true}
+	fileStyle := col.LightDark{Light: plain, Dark: dim}
+	if col.LightBackground {
+		// main and accent colors are swapped by LightDark.Get
+		fileStyle = col.LightDark{Light: dim, Dark: plain}
+	}
+	for key := range c.File {
+		c.File[key] = fileStyle
+	}
+	bold := &col.Style{Bold: true}
+	c.Merge(&col.Colors{
+		Dir: col.DirColors{
+			Name:       bold,
+			Ext:        bold,
+			HiddenName: &col.Style{Bold: true, Dim: true},
+			HiddenExt:  &col.Style{Bold: true, Dim: true},
+		},
+		Link: col.LinkColors{
+			Name:    &col.Style{Italic: true},
+			NameDir: &col.Style{Bold: true, Italic: true},
+			Broken:  &col.Style{Italic: true, Strike: true},
+			Orphan:  &col.Style{Italic: true, Strike: true},
+		},
+		Tabular: &col.TabularColors{
+			FolderHeader: col.FolderHeaderColors{
+				Main:       bold,
+				LastFolder: &col.Style{Bold: true, Underline: true},
+				Error:      &col.Style{Bold: true, Reverse: true},
+			},
+			TableHeader: &col.Style{Bold: true, Underline: true},
+		},
+		Exec:   &col.Style{Bold: true, Underline: true},
+		Device: &col.Style{Underline: true},
+		Socket: &col.Style{Underline: true},
+		Pipe:   &col.Style{Underline: true},
+	})
+}
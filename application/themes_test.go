@@ -0,0 +1,46 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ilius/is/v2"
+
+	col "github.com/ilius/ls-go/lscolors"
+)
+
+func TestApplyThemeFile(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	writeTheme := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		err := os.WriteFile(path, []byte(content), 0o644)
+		is.NotErr(err)
+		return path
+	}
+	basePath := writeTheme("base.json", `{
+		"base": "monochrome-bold",
+		"colors": {"dir": {"name": {"fg": 33}}}
+	}`)
+	path := writeTheme("mine.json", `{
+		"base": "`+basePath+`",
+		"colors": {"dir": {"ext": {"fg": 34}}}
+	}`)
+	c := &col.Colors{
+		Dir:  col.DirColors{Name: col.Fg(1), Ext: col.Fg(2)},
+		Link: col.LinkColors{Arrow: col.Fg(3)},
+	}
+	is.NotErr(applyTheme(c, path))
+	is.Equal(c.Dir.Name, col.Fg(33))
+	is.Equal(c.Dir.Ext, col.Fg(34))
+	// from monochrome-bold
+	is.Equal(c.Link.Arrow, &col.Style{})
+
+	badPath := writeTheme("bad.json", `{"colors": {"dir": {"nme": {"fg": 1}}}}`)
+	is.ErrMsg(applyTheme(c, badPath), badPath+`: colors: unknown key "dir.nme"`)
+
+	loopPath := filepath.Join(dir, "loop.json")
+	writeTheme("loop.json", `{"base": "`+loopPath+`"}`)
+	is.ErrMsg(applyTheme(c, loopPath), `theme "`+loopPath+`": too many nested base themes`)
+}
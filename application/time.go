@@ -43,13 +43,15 @@ func (f *TimeGetter) format(tm *time.Time) string {
 	if tm.IsZero() {
 		return ""
 	}
-	if f.Relative {
-		return lstime.FormatDuration(tm.Sub(*startTime))
-	}
-	if f.UnixFormatStr != "" {
-		return Strftime(tm, f.UnixFormatStr)
+	return f.FormatTime(*tm, *startTime)
+}
+
+// formatData is for json and csv output (ValueString)
+func (f *TimeGetter) formatData(tm *time.Time) string {
+	if tm == nil || tm.IsZero() {
+		return ""
 	}
-	return tm.Format(f.FormatStr)
+	return f.FormatData(*tm, *startTime)
 }
 
 func (f *TimeGetter) Format(_ any, value any) (string, error) {
@@ -57,6 +59,12 @@ func (f *TimeGetter) Format(_ any, value any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("invalid time type %T", value)
 	}
+	if f.Relative && tm != nil && !tm.IsZero() {
+		style := colors.Time.Age[lstime.AgeKey(tm.Sub(*startTime))]
+		if style != nil {
+			return app.Colorize(f.format(tm), style) + " ", nil
+		}
+	}
 	return colorizeTimeStr(f.format(tm)) + " ", nil
 }
 
@@ -84,6 +92,10 @@ func colorizeTimeStr(strPlain string) string {
 	words := strings.Split(strPlain, " ")
 	colored := make([]string, len(words))
 	for i, word := range words {
+		if word == "" {
+			// padding, like "Mar  5"
+			continue
+		}
 		colored[i] = app.Colorize(word, timeWordColor(word))
 	}
 	return strings.Join(colored, " ")
@@ -108,7 +120,7 @@ func (f *MTimeGetter) ValueString(colName string, item any) (string, error) {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
 	_time := info.ModTime()
-	return app.FormatValue(colName, f.format(&_time))
+	return app.FormatValue(colName, f.formatData(&_time))
 }
 
 type CTimeGetter struct {
@@ -129,7 +141,7 @@ func (f *CTimeGetter) ValueString(colName string, item any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
-	return app.FormatValue(colName, f.format(info.CTime()))
+	return app.FormatValue(colName, f.formatData(info.CTime()))
 }
 
 type ATimeGetter struct {
@@ -150,5 +162,5 @@ func (f *ATimeGetter) ValueString(colName string, item any) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
-	return app.FormatValue(colName, f.format(info.ATime()))
+	return app.FormatValue(colName, f.formatData(info.ATime()))
 }
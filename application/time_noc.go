@@ -18,13 +18,15 @@ func (f *TimeGetterPlain) format(tm *time.Time) string {
 	if tm.IsZero() {
 		return ""
 	}
-	if f.Relative {
-		return lstime.FormatDuration(time.Until(*tm))
-	}
-	if f.UnixFormatStr != "" {
-		return Strftime(tm, f.UnixFormatStr)
+	return f.FormatTime(*tm, *startTime)
+}
+
+// formatData is for json and csv output (ValueString)
+func (f *TimeGetterPlain) formatData(tm *time.Time) string {
+	if tm == nil || tm.IsZero() {
+		return ""
 	}
-	return tm.Format(f.FormatStr)
+	return f.FormatData(*tm, *startTime)
 }
 
 func (f *TimeGetterPlain) Format(_ any, value any) (string, error) {
@@ -54,7 +56,7 @@ func (f *MTimeGetterPlain) ValueString(colName string, item any) (string, error)
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
 	_time := info.ModTime()
-	return app.FormatValue(colName, f.format(&_time))
+	return app.FormatValue(colName, f.formatData(&_time))
 }
 
 type CTimeGetterPlain struct {
@@ -75,7 +77,7 @@ func (f *CTimeGetterPlain) ValueString(colName string, item any) (string, error)
 	if !ok {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
-	return app.FormatValue(colName, f.format(info.CTime()))
+	return app.FormatValue(colName, f.formatData(info.CTime()))
 }
 
 type ATimeGetterPlain struct {
@@ -96,5 +98,5 @@ func (f *ATimeGetterPlain) ValueString(colName string, item any) (string, error)
 	if !ok {
 		return "", fmt.Errorf("ValueString: invalid type %T, must be FileInfo", item)
 	}
-	return app.FormatValue(colName, f.format(info.ATime()))
+	return app.FormatValue(colName, f.formatData(info.ATime()))
 }
@@ -21,8 +21,18 @@ const (
 	C_MTime      = "mtime"
 	C_CTime      = "ctime"
 	C_ATime      = "atime"
+	C_BTime      = "btime" // birth time, only used in filters (not a column)
 	C_Name       = "name"
 	C_LinkTarget = "link_target"
+
+	C_FsType      = "fs_type"
+	C_MountSource = "mount_source"
+	C_MountPoint  = "mount_point"
+
+	C_LinkGroup = "link_group"
+	C_DevInode  = "dev_inode"
+
+	C_GroupKey = "group_key"
 )
 
 // quoting styles
@@ -37,3 +47,11 @@ const (
 	E_c                   = "c"
 	E_escape              = "escape"
 )
+
+// indicator styles, for --indicator-style and --data-indicator-style
+const (
+	I_none      = "none"
+	I_slash     = "slash"
+	I_file_type = "file-type"
+	I_classify  = "classify"
+)
@@ -0,0 +1,26 @@
+package common
+
+import "strconv"
+
+// DiskUsage holds free (available to unprivileged users) and total
+// space of a filesystem, in bytes
+type DiskUsage struct {
+	Free  uint64
+	Total uint64
+}
+
+// FormatSizeShort formats size with powers of 1024 and one decimal, like "1.5G"
+// used where we don't have a size column getter, like folder headers
+func FormatSizeShort(size uint64) string {
+	const units = "KMGTPE"
+	if size < 1024 {
+		return strconv.FormatUint(size, 10) + "B"
+	}
+	value := float64(size)
+	unit := -1
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + units[unit:unit+1]
+}
@@ -0,0 +1,13 @@
+package common
+
+import "strconv"
+
+// GroupSummary returns number of items and total size of a group
+// (--group-by), like "3 items, 1.5K"
+func GroupSummary(itemCount int, size uint64) string {
+	items := "items"
+	if itemCount == 1 {
+		items = "item"
+	}
+	return strconv.Itoa(itemCount) + " " + items + ", " + FormatSizeShort(size)
+}
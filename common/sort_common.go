@@ -15,4 +15,21 @@ const (
 	S_FILESIZE  = "filesize"
 	S_MODE      = "mode"
 	S_NAME_LEN  = "name-len"
+
+	S_INODE_GROUP = "inode-group"
+
+	// value of --sort-expr
+	S_EXPR = "expr"
+)
+
+// group by (values of --group-by=)
+const (
+	G_EXT   = "ext"
+	G_KIND  = "kind"
+	G_OWNER = "owner"
+	G_TYPE  = "type"
+	G_AGE   = "age"
+
+	// value of --group-expr
+	G_EXPR = "expr"
 )
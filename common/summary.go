@@ -0,0 +1,72 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SummaryTimeFormat is used for newest and oldest times in folder summary
+const SummaryTimeFormat = "2006-01-02 15:04"
+
+// FolderSummary is the summary of listed items of a directory
+type FolderSummary struct {
+	Path   string     `json:"path"`
+	Dirs   int        `json:"dirs"`
+	Files  int        `json:"files"`
+	Size   uint64     `json:"size"`   // total size of files (not directories)
+	Blocks uint64     `json:"blocks"` // total blocks, in units of block size
+	Newest *time.Time `json:"newest,omitempty"`
+	Oldest *time.Time `json:"oldest,omitempty"`
+}
+
+// SummaryRecordTitles are titles of items of FolderSummary.Record
+// used as header of summary in csv
+var SummaryRecordTitles = []string{
+	"record",
+	"path",
+	"dirs",
+	"files",
+	"size",
+	"blocks",
+	"newest",
+	"oldest",
+}
+
+// Record returns summary as a list of strings, used for csv and json-array
+// first item is the type of record: "summary"
+func (s *FolderSummary) Record() []string {
+	formatTime := func(tm *time.Time) string {
+		if tm == nil {
+			return ""
+		}
+		return tm.Format(time.RFC3339Nano)
+	}
+	return []string{
+		"summary",
+		s.Path,
+		strconv.Itoa(s.Dirs),
+		strconv.Itoa(s.Files),
+		strconv.FormatUint(s.Size, 10),
+		strconv.FormatUint(s.Blocks, 10),
+		formatTime(s.Newest),
+		formatTime(s.Oldest),
+	}
+}
+
+// String returns summary as shown after listing a directory (--summary)
+// like "Σ 2 dirs, 3 files, 1.5K, newest 2026-01-02 15:04, oldest ..."
+func (s *FolderSummary) String() string {
+	parts := []string{
+		strconv.Itoa(s.Dirs) + " dirs",
+		strconv.Itoa(s.Files) + " files",
+		FormatSizeShort(s.Size),
+	}
+	if s.Newest != nil {
+		parts = append(parts, "newest "+s.Newest.Format(SummaryTimeFormat))
+	}
+	if s.Oldest != nil {
+		parts = append(parts, "oldest "+s.Oldest.Format(SummaryTimeFormat))
+	}
+	return "Σ " + strings.Join(parts, ", ")
+}
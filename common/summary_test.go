@@ -0,0 +1,19 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestFolderSummaryString(t *testing.T) {
+	is := is.New(t)
+	summary := &FolderSummary{Dirs: 2, Files: 3, Size: 1536}
+	is.Equal(summary.String(), "Σ 2 dirs, 3 files, 1.5K")
+	newest := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	oldest := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+	summary.Newest = &newest
+	summary.Oldest = &oldest
+	is.Equal(summary.String(), "Σ 2 dirs, 3 files, 1.5K, newest 2026-01-02 15:04, oldest 2025-12-01 08:00")
+}
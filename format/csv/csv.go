@@ -93,6 +93,24 @@ func (*CsvFormatter) FolderHeader(_ io.Writer, _ string, _ int) {}
 
 func (*CsvFormatter) FolderTail(_ io.Writer, _ string) {}
 
+// GroupHeader does nothing, group key is a column (group_key)
+func (*CsvFormatter) GroupHeader(_ io.Writer, _ string, _ int, _ uint64) {}
+
+// TotalBlocks does nothing, blocks is a field of summary record
+func (*CsvFormatter) TotalBlocks(_ io.Writer, _ string) {}
+
+// FolderSummary writes summary as a separate section with its own header
+// (like table of each directory), because it has different columns
+func (f *CsvFormatter) FolderSummary(w io.Writer, summary *FolderSummary) {
+	cw := csv.NewWriter(w)
+	if !*f.args.NoHeader {
+		check(cw.Write(SummaryRecordTitles))
+	}
+	check(cw.Write(summary.Record()))
+	cw.Flush()
+	check(cw.Error())
+}
+
 func (f *CsvFormatter) TableHeader(w io.Writer, tableObj *table.Table) {
 	if *f.args.NoHeader {
 		return
@@ -108,6 +126,10 @@ func (f *CsvFormatter) Colorize(str string, _ *lscolors.Style) string {
 	return str
 }
 
+func (*CsvFormatter) Hyperlink(str string, _ string) string {
+	return str
+}
+
 // previously csvString
 func (f *CsvFormatter) FormatValue(_ string, value any) (string, error) {
 	// _: colName
@@ -1,6 +1,12 @@
 package csv
 
 import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+	"github.com/ilius/ls-go/common"
 	"github.com/ilius/ls-go/iface"
 	"github.com/ilius/ls-go/lsargs"
 )
@@ -8,3 +14,27 @@ import (
 func init() {
 	var _ iface.Formatter = New(&lsargs.Arguments{})
 }
+
+func TestFolderSummary(t *testing.T) {
+	is := is.New(t)
+	noHeader := false
+	f := New(&lsargs.Arguments{NoHeader: &noHeader})
+	newest := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	summary := &common.FolderSummary{
+		Path:   "dir",
+		Dirs:   1,
+		Files:  2,
+		Size:   300,
+		Blocks: 8,
+		Newest: &newest,
+	}
+	buf := bytes.NewBuffer(nil)
+	f.FolderSummary(buf, summary)
+	is.Equal(buf.String(), "record,path,dirs,files,size,blocks,newest,oldest\n"+
+		"summary,dir,1,2,300,8,2026-01-02T15:04:05Z,\n")
+
+	noHeader = true
+	buf.Reset()
+	f.FolderSummary(buf, summary)
+	is.Equal(buf.String(), "summary,dir,1,2,300,8,2026-01-02T15:04:05Z,\n")
+}
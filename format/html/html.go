@@ -2,6 +2,7 @@ package csv
 
 import (
 	"fmt"
+	"html"
 	"io"
 	"os"
 	"strconv"
@@ -27,6 +28,7 @@ type AppInterface interface {
 	Dir(path string) string
 	Join(elem ...string) string
 	SplitAll(path string) []string
+	DiskUsage(path string) (*DiskUsage, error)
 }
 
 func New(
@@ -122,7 +124,7 @@ func (f *HtmlFormatter) FolderHeader(w io.Writer, path string, itemCount int) {
 	if len(*f.args.Find) > 0 && itemCount == 0 {
 		return
 	}
-	if len(f.args.Paths) == 1 && f.args.Paths[0] == "." && !*f.args.Recursive {
+	if len(f.args.Paths) == 1 && f.args.Paths[0] == "." && !*f.args.Recursive && !*f.args.Mounts {
 		return
 	}
 	fhColors := f.colors.FolderHeader
@@ -149,6 +151,15 @@ func (f *HtmlFormatter) FolderHeader(w io.Writer, path string, itemCount int) {
 	if f.colors != nil {
 		headerString += " "
 	}
+	if *f.args.Mounts {
+		du, err := f.app.DiskUsage(path)
+		if err == nil {
+			headerString += " " + f.Colorize(
+				FormatSizeShort(du.Free)+" free of "+FormatSizeShort(du.Total),
+				fhColors.Space,
+			)
+		}
+	}
 	fmt.Fprintln(w, headerString)
 }
 
@@ -156,6 +167,24 @@ func (*HtmlFormatter) FolderTail(w io.Writer, _ string) {
 	fmt.Fprintln(w, "<br/>")
 }
 
+func (*HtmlFormatter) TotalBlocks(w io.Writer, total string) {
+	fmt.Fprintln(w, "total "+total)
+}
+
+func (f *HtmlFormatter) FolderSummary(w io.Writer, summary *FolderSummary) {
+	fmt.Fprintln(w, f.Colorize(summary.String(), f.colors.Summary))
+}
+
+func (f *HtmlFormatter) GroupHeader(w io.Writer, label string, itemCount int, size uint64) {
+	ghColors := f.colors.GroupHeader
+	fmt.Fprintln(
+		w,
+		f.Colorize("▸", ghColors.Arrow)+" "+
+			f.Colorize(html.EscapeString(label), ghColors.Label)+" "+
+			f.Colorize("("+GroupSummary(itemCount, size)+")", ghColors.Summary),
+	)
+}
+
 func (*HtmlFormatter) TableHeader(w io.Writer, tableObj *table.Table) {
 	h := []string{}
 	for _, col := range tableObj.Columns {
@@ -164,23 +193,52 @@ func (*HtmlFormatter) TableHeader(w io.Writer, tableObj *table.Table) {
 	fmt.Fprintln(w, strings.Join(h, ","))
 }
 
+func (*HtmlFormatter) Hyperlink(str string, url string) string {
+	return `<a href="` + url + `" style="color:inherit">` + str + `</a>`
+}
+
 func (f *HtmlFormatter) Colorize(str string, style *lscolors.Style) string {
 	def := f.colors.Default
-	fg := def.Fg
-	if style.Fg > 0 {
-		fg = style.Fg
+	fg := def.FgHex()
+	if hex := style.FgHex(); hex != "" {
+		fg = hex
 	}
-	bg := def.Bg
-	if style.Bg > 0 {
-		bg = style.Bg
+	bg := def.BgHex()
+	if hex := style.BgHex(); hex != "" {
+		bg = hex
+	}
+	if fg == "" {
+		fg = lscolors.TermColorsHex[0]
+	}
+	if bg == "" {
+		bg = lscolors.TermColorsHex[0]
+	}
+	if style.Reverse {
+		fg, bg = bg, fg
 	}
 	css := []string{
-		`color:` + lscolors.TermColorsHex[int(fg)],
-		`background:` + lscolors.TermColorsHex[int(bg)],
+		`color:` + fg,
+		`background:` + bg,
 	}
 	if style.Bold {
 		css = append(css, `font-weight:bold`)
 	}
+	if style.Dim {
+		css = append(css, `opacity:0.6`)
+	}
+	if style.Italic {
+		css = append(css, `font-style:italic`)
+	}
+	decorations := []string{}
+	if style.Underline {
+		decorations = append(decorations, "underline")
+	}
+	if style.Strike {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		css = append(css, `text-decoration:`+strings.Join(decorations, " "))
+	}
 	if strings.HasPrefix(str, " ") {
 		str = "&nbsp;" + strings.Trim(str, " ") + "&nbsp;"
 	}
@@ -203,7 +261,10 @@ func (f *HtmlFormatter) FormatItem(tableObj *table.Table, item any) ([]string, e
 }
 
 func (f *HtmlFormatter) PrintItems(w io.Writer, _ *table.Table, items iface.FormattedItemList) error {
-	bgColor := lscolors.TermColorsHex[int(f.colors.Default.Bg)]
+	bgColor := f.colors.Default.BgHex()
+	if bgColor == "" {
+		bgColor = lscolors.TermColorsHex[0]
+	}
 	fmt.Fprintf(w, `<table style="background-color:%s;font-family: monospace;">\n`, bgColor)
 	for i := 0; i < items.Len(); i++ {
 		row := items.Get(i)
@@ -211,9 +272,13 @@ func (f *HtmlFormatter) PrintItems(w io.Writer, _ *table.Table, items iface.Form
 		for i, cell := range row {
 			tdList[i] = "<td>" + cell + "</td>"
 		}
+		tr := "<tr>"
+		if style := items.Highlight(i); style != nil && style.BgHex() != "" {
+			tr = `<tr style="background:` + style.BgHex() + `">`
+		}
 		fmt.Fprintln(
 			w,
-			"<tr>"+strings.Join(tdList, "")+"</tr>",
+			tr+strings.Join(tdList, "")+"</tr>",
 		)
 	}
 	fmt.Fprintln(w, "</table>")
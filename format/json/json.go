@@ -72,6 +72,30 @@ func (*JsonFormatter) FolderHeader(_ io.Writer, _ string, _ int) {}
 
 func (*JsonFormatter) FolderTail(_ io.Writer, _ string) {}
 
+// GroupHeader does nothing, group key is a column (group_key)
+func (*JsonFormatter) GroupHeader(_ io.Writer, _ string, _ int, _ uint64) {}
+
+// FolderSummaryJSON is a summary record, "record" field tells it apart
+// from file items
+type FolderSummaryJSON struct {
+	Record string `json:"record"`
+	*FolderSummary
+}
+
+// TotalBlocks does nothing, blocks is a field of summary record
+func (*JsonFormatter) TotalBlocks(_ io.Writer, _ string) {}
+
+func (*JsonFormatter) FolderSummary(w io.Writer, summary *FolderSummary) {
+	jsonB, err := json.Marshal(FolderSummaryJSON{
+		Record:        "summary",
+		FolderSummary: summary,
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintln(w, string(jsonB))
+}
+
 func (f *JsonFormatter) TableHeader(w io.Writer, tableObj *table.Table) {
 	if !*f.args.Header {
 		return
@@ -91,6 +115,10 @@ func (f *JsonFormatter) Colorize(str string, _ *lscolors.Style) string {
 	return str
 }
 
+func (*JsonFormatter) Hyperlink(str string, _ string) string {
+	return str
+}
+
 func (f *JsonFormatter) FormatValue(colName string, value any) (string, error) {
 	return jsonKeyValue(colName, value, f.ensure_ascii)
 }
@@ -73,6 +73,20 @@ func (*JsonArrayFormatter) FolderHeader(_ io.Writer, _ string, _ int) {}
 
 func (*JsonArrayFormatter) FolderTail(_ io.Writer, _ string) {}
 
+// GroupHeader does nothing, group key is a column (group_key)
+func (*JsonArrayFormatter) GroupHeader(_ io.Writer, _ string, _ int, _ uint64) {}
+
+// TotalBlocks does nothing, blocks is a field of summary record
+func (*JsonArrayFormatter) TotalBlocks(_ io.Writer, _ string) {}
+
+func (*JsonArrayFormatter) FolderSummary(w io.Writer, summary *FolderSummary) {
+	jsonB, err := json.Marshal(summary.Record())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintln(w, string(jsonB))
+}
+
 func (f *JsonArrayFormatter) TableHeader(w io.Writer, tableObj *table.Table) {
 	if *f.args.NoHeader {
 		return
@@ -93,6 +107,10 @@ func (f *JsonArrayFormatter) Colorize(str string, _ *lscolors.Style) string {
 	return str
 }
 
+func (*JsonArrayFormatter) Hyperlink(str string, _ string) string {
+	return str
+}
+
 func (f *JsonArrayFormatter) FormatValue(_ string, value any) (string, error) {
 	// _: colName
 	j_value, err := json.Marshal(value)
@@ -0,0 +1,62 @@
+package tabular
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// go-table only ignores CSI escape sequences (like colors) when calculating
+// width of cells, not OSC 8 hyperlinks (which contain the URL), so while
+// formatting items we use placeholders that look like CSI sequences (with an
+// index in f.hyperlinks), and replace them with OSC 8 sequences when printing
+
+// hyperlinkEnd is the placeholder for end of hyperlink
+const hyperlinkEnd = "\x1b[y"
+
+var hyperlinkStartRE = regexp.MustCompile(`\x1b\[(\d{1,4});(\d{1,4})y`)
+
+func hyperlinkStart(index int) string {
+	// go-table accepts numbers of up to 4 digits
+	return "\x1b[" + strconv.Itoa(index/10000) + ";" + strconv.Itoa(index%10000) + "y"
+}
+
+func (f *TabularFormatter) Hyperlink(str string, url string) string {
+	index := len(f.hyperlinks)
+	f.hyperlinks = append(f.hyperlinks, url)
+	return hyperlinkStart(index) + str + hyperlinkEnd
+}
+
+// resolveHyperlinks replaces hyperlink placeholders with OSC 8 sequences
+func (f *TabularFormatter) resolveHyperlinks(str string) string {
+	if len(f.hyperlinks) == 0 {
+		return str
+	}
+	str = hyperlinkStartRE.ReplaceAllStringFunc(str, func(match string) string {
+		groups := hyperlinkStartRE.FindStringSubmatch(match)
+		high, _ := strconv.Atoi(groups[1])
+		low, _ := strconv.Atoi(groups[2])
+		index := high*10000 + low
+		if index >= len(f.hyperlinks) {
+			return ""
+		}
+		return "\x1b]8;;" + f.hyperlinks[index] + "\x1b\\"
+	})
+	return strings.ReplaceAll(str, hyperlinkEnd, "\x1b]8;;\x1b\\")
+}
+
+// hyperlinkWriter resolves hyperlink placeholders of lines written by
+// go-table (one line per Write call)
+type hyperlinkWriter struct {
+	w io.Writer
+	f *TabularFormatter
+}
+
+func (hw *hyperlinkWriter) Write(p []byte) (int, error) {
+	_, err := io.WriteString(hw.w, hw.f.resolveHyperlinks(string(p)))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,43 @@
+package tabular
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ilius/go-table"
+	"github.com/ilius/is/v2"
+	"github.com/ilius/ls-go/lsargs"
+)
+
+func TestHyperlink(t *testing.T) {
+	is := is.New(t)
+	f := &TabularFormatter{}
+	for i := 0; i < 12345; i++ {
+		f.Hyperlink("x", "file:///x")
+	}
+	cell := f.Hyperlink("a.txt", "file://host/tmp/a.txt")
+	// placeholders must have zero width for go-table
+	is.Equal(table.AlignmentLeft(cell, 7), cell+"  ")
+	is.Equal(
+		f.resolveHyperlinks(cell+"  "),
+		"\x1b]8;;file://host/tmp/a.txt\x1b\\a.txt\x1b]8;;\x1b\\  ",
+	)
+
+	buf := bytes.NewBuffer(nil)
+	w := &hyperlinkWriter{w: buf, f: f}
+	n, err := w.Write([]byte(cell + "\n"))
+	is.NotErr(err)
+	is.Equal(n, len(cell)+1)
+	is.Equal(buf.String(), "\x1b]8;;file://host/tmp/a.txt\x1b\\a.txt\x1b]8;;\x1b\\\n")
+}
+
+func TestHyperlinkResetByFolderHeader(t *testing.T) {
+	is := is.New(t)
+	find := "x"
+	f := &TabularFormatter{args: &lsargs.Arguments{Find: &find}}
+	f.Hyperlink("a.txt", "file:///dir1/a.txt")
+	f.FolderHeader(bytes.NewBuffer(nil), "dir2", 0)
+	is.Equal(len(f.hyperlinks), 0)
+	cell := f.Hyperlink("b.txt", "file:///dir2/b.txt")
+	is.Equal(cell, hyperlinkStart(0)+"b.txt"+hyperlinkEnd)
+}
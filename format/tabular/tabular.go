@@ -25,6 +25,7 @@ type AppInterface interface {
 	Join(elem ...string) string
 	SplitAll(path string) []string
 	JoinColor(color string, reset string, elem ...string) string
+	DiskUsage(path string) (*DiskUsage, error)
 }
 
 func New(
@@ -43,6 +44,9 @@ type TabularFormatter struct {
 	app    AppInterface
 	args   *lsargs.Arguments
 	colors *lscolors.TabularColors
+
+	// URLs of hyperlinks, see Hyperlink
+	hyperlinks []string
 }
 
 func check(err error) {
@@ -117,10 +121,12 @@ func (f *TabularFormatter) PrintError(w io.Writer, err error) {
 }
 
 func (f *TabularFormatter) FolderHeader(w io.Writer, path string, itemCount int) {
+	// hyperlinks of previous directory are already printed
+	f.hyperlinks = nil
 	if len(*f.args.Find) > 0 && itemCount == 0 {
 		return
 	}
-	if len(f.args.Paths) == 1 && f.args.Paths[0] == "." && !*f.args.Recursive {
+	if len(f.args.Paths) == 1 && f.args.Paths[0] == "." && !*f.args.Recursive && !*f.args.Mounts {
 		return
 	}
 	if f.colors != nil {
@@ -130,6 +136,19 @@ func (f *TabularFormatter) FolderHeader(w io.Writer, path string, itemCount int)
 	f.folderHeaderNoColor(w, path, itemCount)
 }
 
+// diskUsageString returns free and total space of the filesystem
+// containing path, if enabled with --mounts
+func (f *TabularFormatter) diskUsageString(path string) string {
+	if !*f.args.Mounts {
+		return ""
+	}
+	du, err := f.app.DiskUsage(path)
+	if err != nil {
+		return ""
+	}
+	return FormatSizeShort(du.Free) + " free of " + FormatSizeShort(du.Total)
+}
+
 func (f *TabularFormatter) folderHeader(w io.Writer, path string, _ int) {
 	// _: itemCount
 	fhColors := f.colors.FolderHeader
@@ -152,8 +171,9 @@ func (f *TabularFormatter) folderHeader(w io.Writer, path string, _ int) {
 		headerString += f.app.JoinColor(fhColors.Slash.S(), "", coloredFolders...)
 	}
 
-	if f.colors != nil {
-		headerString += " " + Reset
+	headerString += " " + Reset
+	if space := f.diskUsageString(path); space != "" {
+		headerString += " " + f.Colorize(space, fhColors.Space)
 	}
 	fmt.Fprintln(w, headerString)
 }
@@ -171,8 +191,8 @@ func (f *TabularFormatter) folderHeaderNoColor(w io.Writer, path string, _ int)
 		headerString += f.app.Join(folders...)
 	}
 
-	if f.colors != nil {
-		headerString += " " + Reset
+	if space := f.diskUsageString(path); space != "" {
+		headerString += "  " + space
 	}
 	fmt.Fprintln(w, headerString)
 }
@@ -181,6 +201,33 @@ func (*TabularFormatter) FolderTail(w io.Writer, _ string) {
 	fmt.Fprintln(w, "")
 }
 
+func (*TabularFormatter) TotalBlocks(w io.Writer, total string) {
+	fmt.Fprintln(w, "total "+total)
+}
+
+func (f *TabularFormatter) FolderSummary(w io.Writer, summary *FolderSummary) {
+	str := summary.String()
+	if f.colors != nil {
+		str = f.Colorize(str, f.colors.Summary)
+	}
+	fmt.Fprintln(w, str)
+}
+
+func (f *TabularFormatter) GroupHeader(w io.Writer, label string, itemCount int, size uint64) {
+	summary := GroupSummary(itemCount, size)
+	if f.colors == nil {
+		fmt.Fprintln(w, "▸ "+label+"  ("+summary+")")
+		return
+	}
+	ghColors := f.colors.GroupHeader
+	fmt.Fprintln(
+		w,
+		f.Colorize("▸", ghColors.Arrow)+" "+
+			f.Colorize(label, ghColors.Label)+"  "+
+			f.Colorize("("+summary+")", ghColors.Summary),
+	)
+}
+
 func (f *TabularFormatter) getSep() string {
 	if *f.args.Vbar {
 		return " | "
@@ -259,10 +306,18 @@ func (f *TabularFormatter) PrintItems(w io.Writer, tableObj *table.Table, items
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(w, strings.Join(aligned, sep))
+			line := strings.Join(aligned, sep)
+			if style := items.Highlight(index); style != nil {
+				line = style.Highlight(line)
+			}
+			fmt.Fprintln(w, f.resolveHyperlinks(line))
 		}
 		return nil
 	}
+	items = highlightedItemList{items}
+	if len(f.hyperlinks) > 0 {
+		w = &hyperlinkWriter{w: w, f: f}
+	}
 
 	// format in columns, like `ls` or `ls -x`
 	maxWidth, err := f.app.TermWidth()
@@ -276,3 +331,22 @@ func (f *TabularFormatter) PrintItems(w io.Writer, tableObj *table.Table, items
 	}
 	return nil
 }
+
+// highlightedItemList applies row highlight of items to each cell, used
+// when printing multiple files per line
+type highlightedItemList struct {
+	iface.FormattedItemList
+}
+
+func (list highlightedItemList) Get(index int) []string {
+	row := list.FormattedItemList.Get(index)
+	style := list.Highlight(index)
+	if style == nil {
+		return row
+	}
+	result := make([]string, len(row))
+	for i, cell := range row {
+		result[i] = style.Highlight(cell)
+	}
+	return result
+}
@@ -17,10 +17,12 @@ type FileInfo interface {
 	DirAbs() string
 	PathAbs() string
 	PathDisplay() string
+	Depth() int
 	Time(colName string) *time.Time
 	Owner() string
 	Group() string
 	Inode() (uint64, error)
+	DeviceID() (uint64, error)
 	NumberOfHardLinks() (uint64, error)
 	DeviceNumbers() (string, error)
 	CTime() *time.Time
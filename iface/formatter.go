@@ -11,6 +11,9 @@ import (
 type FormattedItemList interface {
 	Len() int
 	Get(int) []string
+	// Highlight returns row highlight style of item (set by color rules),
+	// or nil
+	Highlight(int) *lscolors.Style
 }
 
 type Formatter interface { //iface:ignore=unused
@@ -37,12 +40,25 @@ type Formatter interface { //iface:ignore=unused
 	//  FolderHeader formats and prints folder tail
 	FolderTail(w io.Writer, path string)
 
+	// GroupHeader formats and prints header of a group of items (--group-by)
+	GroupHeader(w io.Writer, label string, itemCount int, size uint64)
+
+	// TotalBlocks prints total number of blocks of listed items of a
+	// directory (formatted by --block-size etc), like the "total" line of `ls -l`
+	TotalBlocks(w io.Writer, total string)
+
+	// FolderSummary formats and prints summary of listed items of a directory
+	FolderSummary(w io.Writer, summary *common.FolderSummary)
+
 	// TableHeader formats and prints table header
 	TableHeader(w io.Writer, tableObj *table.Table)
 
 	// Colorize adds color to given cell/string
 	Colorize(str string, style *lscolors.Style) string
 
+	// Hyperlink makes str a link to url (for --hyperlink)
+	Hyperlink(str string, url string) string
+
 	// FormatValue formats a cell value
 	// used for csv and json serualization
 	FormatValue(colName string, value any) (string, error)
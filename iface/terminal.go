@@ -1,6 +1,10 @@
 package iface
 
-import "os"
+import (
+	"os"
+
+	"github.com/ilius/ls-go/lscolors"
+)
 
 type Terminal interface {
 	// TermWidth returns terminal width
@@ -11,4 +15,12 @@ type Terminal interface {
 
 	// ColorsEnabled returns true if colors are enabled
 	ColorsEnabled(colorFlag string) (bool, error)
+
+	// ColorDepth returns number of colors supported by terminal
+	ColorDepth(depthFlag string) (lscolors.ColorDepth, error)
+
+	// LightBackground returns true if terminal background is light
+	// query: whether we can query the terminal (if it is not a pipe), which
+	// is only done with --background=query
+	LightBackground(backgroundFlag string, query bool) (bool, error)
 }
@@ -6,6 +6,7 @@ import (
 
 	goopt "github.com/ilius/goopt"
 	. "github.com/ilius/ls-go/common"
+	"github.com/ilius/ls-go/lsconfig"
 )
 
 // declare the struct that holds all the arguments
@@ -13,19 +14,31 @@ type Arguments struct {
 	All       *bool
 	AlmostAll *bool
 	Sort      *string
+	SortExpr  *string
+	GroupBy   *string
+	GroupExpr *string
 	Size      *bool
 	Human     *bool
 	SI        *bool
 	Bytes     *bool
 	Blocks    *bool
 
+	BlockSize    *string
+	Shortcut_k   *bool
+	IEC          *bool
+	SizeDecimals *int
+
 	Time      *string
 	TimeStyle *string
 	FullTime  *bool
+	TimeZone  *string
+	UTC       *bool
 	Mtime     *bool
 	Ctime     *bool
 	Atime     *bool
 
+	RelativeUnits *int
+
 	Owner         *bool
 	Group         *bool
 	NoGroup       *bool
@@ -33,6 +46,7 @@ type Arguments struct {
 	ModeOct       *bool
 	Mode          *bool
 	Inode         *bool
+	LinkGroup     *bool
 
 	Long       *bool
 	ExtraLong  *bool
@@ -47,23 +61,41 @@ type Arguments struct {
 	Shortcut_literal *bool
 	Shortcut_escape  *bool
 
+	IndicatorStyle     *string
+	DataIndicatorStyle *string
+	Shortcut_classify  *bool
+	Shortcut_file_type *bool
+	Shortcut_p         *bool
+
 	Directory *bool
 	DirsFirst *bool
 	DirsOnly  *bool
 	FilesOnly *bool
 	HasMode   *string
 
+	Type        *string
+	Empty       *bool
+	BrokenLinks *bool
+	OwnerIs     *string
+	GroupIs     *string
+	PermIs      *string
+
 	Dereference *bool
 	Links       *bool
 	LinkRel     *bool
 
-	Reverse   *bool
-	Stats     *bool
-	Icons     *bool
-	Nerdfont  *bool
-	Recursive *bool
-	Find      *string
-	Color     *string
+	Reverse    *bool
+	Stats      *bool
+	Summary    *bool
+	Mounts     *bool
+	Icons      *bool
+	Nerdfont   *bool
+	Hyperlink  *string
+	Recursive  *bool
+	Find       *string
+	Color      *string
+	ColorDepth *string
+	Background *string
 
 	Header   *bool
 	NoHeader *bool
@@ -76,8 +108,16 @@ type Arguments struct {
 
 	ReadJson *bool
 
-	Minsize *int
-	Maxsize *int
+	Minsize *string
+	Maxsize *string
+
+	FilterTime *string
+	Newer      *string
+	Older      *string
+	NewerThan  *string
+	OlderThan  *string
+	After      *string
+	Before     *string
 
 	Shortcut_t *bool
 	Shortcut_c *bool
@@ -85,14 +125,26 @@ type Arguments struct {
 	Shortcut_U *bool
 	Shortcut_S *bool
 	Shortcut_X *bool
+	Shortcut_v *bool
 
-	ColorsJson *bool
+	ColorsJson   *bool
+	LsColors     *bool
+	Theme        *string
+	ThemePreview *bool
+	ColorRule    *[]string
 
-	Expr  *string
+	Expr  *[]string
 	Where *string
 
 	CpuProfile *string
 
+	Profile     *string
+	NoConfig    *bool
+	PrintConfig *bool
+
+	// Config is the result of merging config file, profile and command line
+	Config *lsconfig.Effective
+
 	Paths []string
 }
 
@@ -109,69 +161,91 @@ const time_flag_desc = `Change the default of using modification times; Access t
 
 func New() *Arguments {
 	return &Arguments{
-		All: goopt.Flag(
+		All: newFlag(
 			[]string{"--all", "-a"},
 			nil,
 			"Do not ignore entries starting with '.'",
 			"",
 		),
-		AlmostAll: goopt.Flag(
+		AlmostAll: newFlag(
 			[]string{"--almost-all", "-A"},
 			nil,
 			"Do not list implied '.' and '..'",
 			"",
 		),
-		Sort: goopt.Alternatives(
+		Sort: newString(
 			[]string{"--sort"},
-			// first item must be "" because that is the value we get when flag is not passed
-			[]string{
-				"",
-				S_NONE,
-				S_SIZE,
-				S_NAME,
-				S_BASENAME,
-				S_TIME,
-				S_EXTENSION,
-				S_KIND,
-				S_INODE,
-				S_LINKS,
-				S_FILESIZE,
-				S_MODE,
-				S_NAME_LEN,
-			},
-			"Sort by given column instead of basename",
+			"",
+			"Sort by given column (or comma-separated columns) instead of basename: name, basename, size, filesize, time, version, extension (or ext), kind, inode, links, mode, name-len, inode-group, expr (with --sort-expr) or none; Prefix a column with - to reverse it, for example --sort=ext,-size,name",
+		),
+		SortExpr: newString(
+			[]string{"--sort-expr"},
+			"",
+			"An expression to sort by (before --sort columns, unless 'expr' is given in --sort)",
+		),
+		GroupBy: newString(
+			[]string{"--group-by"},
+			"",
+			"Split items into groups (sections) by: ext, kind, owner, type, age or expr",
+		),
+		GroupExpr: newString(
+			[]string{"--group-expr"},
+			"",
+			"An expression to group items by (implies --group-by=expr)",
 		),
-		Size: goopt.Flag(
+		Size: newFlag(
 			[]string{"--size", "-s"},
 			nil,
 			"Print the size of each file",
 			"",
 		),
-		Human: goopt.Flag(
+		Human: newFlag(
 			[]string{"--human-readable", "-h"},
 			nil,
 			"With -l or -s / --size, print sizes like '1K', '234M', '2G', etc",
 			"",
 		),
-		SI: goopt.Flag(
+		SI: newFlag(
 			[]string{"--si"},
 			nil,
 			"Use metric system for size. Like --human-readable, but use powers of 1000, not 1024",
 			"",
 		),
-		Bytes: goopt.Flag(
+		Bytes: newFlag(
 			[]string{"--bytes"},
 			nil,
 			"Print sizes in bytes",
 			"",
 		),
-		Blocks: goopt.Flag(
+		Blocks: newFlag(
 			[]string{"--blocks"},
 			nil,
 			"Show allocated number of blocks (like ls -s) as a new column",
 			"",
 		),
-		Time: goopt.Alternatives(
+		BlockSize: newString(
+			[]string{"--block-size"},
+			"",
+			"Scale sizes and blocks by SIZE, like 1K, M (also shown after sizes), KB (1000), KiB, '1K (with thousands separator), human-readable or si",
+		),
+		Shortcut_k: newFlag(
+			[]string{"-k", "--kibibytes"},
+			nil,
+			"Use 1024-byte blocks for blocks column and total line (overrides --block-size)",
+			"",
+		),
+		IEC: newFlag(
+			[]string{"--iec"},
+			nil,
+			"Use IEC suffixes (KiB, MiB, ...) for human-readable sizes",
+			"",
+		),
+		SizeDecimals: newInt(
+			[]string{"--size-decimals"},
+			-1,
+			"Number of decimals in human-readable sizes (default: one decimal if less than 10, like coreutils)",
+		),
+		Time: newAlternatives(
 			[]string{"--time"},
 			[]string{
 				"mtime", "ctime", "atime", // main names
@@ -180,66 +254,82 @@ func New() *Arguments {
 			},
 			time_flag_desc,
 		),
-		TimeStyle: goopt.String(
+		TimeStyle: newString(
 			[]string{"--time-style"},
 			"",
 			"Time/date format with -l; See 'README.md' for details.",
 		),
-		FullTime: goopt.Flag(
+		FullTime: newFlag(
 			[]string{"--full-time"},
 			nil,
 			"Shortcut to -l --time-style=full-iso",
 			"",
 		),
-		Mtime: goopt.Flag(
+		TimeZone: newString(
+			[]string{"--tz"},
+			"",
+			"Time zone for times (of all columns, expressions and formats): local (default), UTC, an offset like +03:30, or a name like Europe/Berlin",
+		),
+		UTC: newFlag(
+			[]string{"--utc"},
+			nil,
+			"Shortcut to --tz=UTC",
+			"",
+		),
+		RelativeUnits: newInt(
+			[]string{"--relative-units"},
+			1,
+			"Maximum number of units with --time-style=relative, for example 2 for '2 weeks, 3 days ago'",
+		),
+		Mtime: newFlag(
 			[]string{"--mtime", "--modified"},
 			nil,
 			"Include modification time (of file contents)",
 			"",
 		),
-		Ctime: goopt.Flag(
+		Ctime: newFlag(
 			[]string{"--ctime", "--changed"},
 			nil,
 			"Include change time (of file contents or metadata)",
 			"",
 		),
-		Atime: goopt.Flag(
+		Atime: newFlag(
 			[]string{"--atime", "--accessed"},
 			nil,
 			"Include access time",
 			"",
 		),
-		Owner: goopt.Flag(
+		Owner: newFlag(
 			[]string{"--owner"},
 			nil,
 			"Include owner and group",
 			"",
 		),
-		Group: goopt.Flag(
+		Group: newFlag(
 			[]string{"--group"},
 			nil,
 			"Show group (without long mode)",
 			"",
 		),
-		NoGroup: goopt.Flag(
+		NoGroup: newFlag(
 			[]string{"--no-group", "-G"},
 			nil,
 			"Hide group name (with -l)",
 			"",
 		),
-		NumericUidGid: goopt.Flag(
+		NumericUidGid: newFlag(
 			[]string{"-n", "--numeric-uid-gid"},
 			nil,
 			"like -l, but list numeric user and group IDs",
 			"",
 		),
-		Mode: goopt.Flag(
+		Mode: newFlag(
 			[]string{"--perm", "--mode"},
 			nil,
 			"Include permissions for owner, group, and other",
 			"",
 		),
-		ModeOct: goopt.Flag(
+		ModeOct: newFlag(
 			[]string{
 				"--perm-oct",
 				"--mode-oct",
@@ -252,25 +342,31 @@ func New() *Arguments {
 			"Include permissions / mode in octal format",
 			"",
 		),
-		Inode: goopt.Flag(
+		Inode: newFlag(
 			[]string{"--inode", "-i"},
 			nil,
 			"Print the index number (inode number) of each file",
 			"",
 		),
-		Long: goopt.Flag(
+		LinkGroup: newFlag(
+			[]string{"--link-group"},
+			nil,
+			"Show the same short tag for items that are hard links to the same file (same device and inode)",
+			"",
+		),
+		Long: newFlag(
 			[]string{"--long", "-l"},
 			nil,
 			"Include size, date, owner, and permissions",
 			"",
 		),
-		ExtraLong: goopt.Flag(
+		ExtraLong: newFlag(
 			[]string{"--extra-long"},
 			nil,
 			"Include all columns",
 			"",
 		),
-		SingleCol: goopt.Flag(
+		SingleCol: newFlag(
 			[]string{"-1", "--oneline"},
 			// commands that have --oneline as long flag for -1
 			// 		git log --oneline
@@ -282,14 +378,14 @@ func New() *Arguments {
 			"",
 		),
 		// like ls -x or ls --format=horizontal
-		Horizontal: goopt.Flag(
+		Horizontal: newFlag(
 			[]string{"--horizontal", "-x"},
 			nil,
 			"list entries by lines instead of by columns",
 			"",
 		),
 		// like ls -C or ls --format=vertical
-		Vertical: goopt.Flag(
+		Vertical: newFlag(
 			[]string{
 				"--vertical",
 				"--grid", // like exa
@@ -298,19 +394,19 @@ func New() *Arguments {
 			"list entries by columns",
 			"",
 		),
-		Compact: goopt.Flag(
+		Compact: newFlag(
 			[]string{"--compact"},
 			nil,
 			"try to fit more columns in many-files-per-line modes (vertical/horizontal)",
 			"",
 		),
-		Vbar: goopt.Flag(
+		Vbar: newFlag(
 			[]string{"--vbar"},
 			nil,
 			"show vertical bars between files in a row, or between columns in '--long' or '--oneline' mode",
 			"",
 		),
-		QuotingStyle: goopt.Alternatives(
+		QuotingStyle: newAlternatives(
 			[]string{"--quoting-style"},
 			[]string{
 				"", // default, must be first
@@ -321,96 +417,187 @@ func New() *Arguments {
 				E_shell_escape_always,
 				E_c,
 				E_escape,
+				E_locale,
 				E_none,
 			},
 			"use given quoting style for entry names (overrides QUOTING_STYLE environment variable)",
 		),
-		Shortcut_literal: goopt.Flag(
+		Shortcut_literal: newFlag(
 			[]string{"--literal", "-N"},
 			nil,
 			"Shortcut to --quoting-style=literal; Print entry names without quoting",
 			"",
 		),
-		Shortcut_escape: goopt.Flag(
+		Shortcut_escape: newFlag(
 			[]string{"--escape", "-b"},
 			nil,
 			"Shortcut to --quoting-style=escape; Print C-style escapes for nongraphic characters",
 			"",
 		),
-		Directory: goopt.Flag(
+		IndicatorStyle: newAlternatives(
+			[]string{"--indicator-style"},
+			[]string{
+				I_none, // default, must be first
+				I_slash,
+				I_file_type,
+				I_classify,
+			},
+			"Append indicator to entry names: none, slash (/ for directories), file-type (/ @ | =), classify (/ @ | = and * for executables)",
+		),
+		DataIndicatorStyle: newAlternatives(
+			[]string{"--data-indicator-style"},
+			[]string{
+				I_slash, // default, must be first
+				I_none,
+				I_file_type,
+				I_classify,
+			},
+			"Indicator style for entry names in json and csv output (--indicator-style is not used for them)",
+		),
+		Shortcut_classify: newFlag(
+			[]string{"--classify", "-F"},
+			nil,
+			"Shortcut to --indicator-style=classify",
+			"",
+		),
+		Shortcut_file_type: newFlag(
+			[]string{"--file-type"},
+			nil,
+			"Shortcut to --indicator-style=file-type",
+			"",
+		),
+		Shortcut_p: newFlag(
+			[]string{"-p"},
+			nil,
+			"Shortcut to --indicator-style=slash",
+			"",
+		),
+		Directory: newFlag(
 			[]string{"--directory", "-d", "--list-dirs"},
 			nil,
 			"List directories themselves, not their contents",
 			"",
 		),
 
-		DirsFirst: goopt.Flag(
+		DirsFirst: newFlag(
 			[]string{"--dirs-first", "--dir-first", "--group-directories-first"},
 			nil,
 			"Show directories before files",
 			"",
 		),
-		DirsOnly: goopt.Flag(
+		DirsOnly: newFlag(
 			[]string{"--dirs-only", "--dir-only", "--only-dirs"},
 			nil,
 			"Only show directories",
 			"",
 		),
-		FilesOnly: goopt.Flag(
+		FilesOnly: newFlag(
 			[]string{"--files"},
 			nil,
 			"Only show files",
 			"",
 		),
-		HasMode: goopt.String(
+		HasMode: newString(
 			[]string{"--has-mode"},
 			"",
-			"Only show items with mode(permissions) that contains the given octal mode",
+			"Only show items with mode(permissions) that contains the given octal mode (same as --perm-is=-MODE)",
+		),
+		Type: newString(
+			[]string{"--type"},
+			"",
+			"Only show items of given types (comma-separated): f (regular file), d (directory), l (symbolic link), p (named pipe), s (socket), b (block device), c (character device), x (executable file)",
+		),
+		Empty: newFlag(
+			[]string{"--empty"},
+			nil,
+			"Only show empty files and directories",
+			"",
+		),
+		BrokenLinks: newFlag(
+			[]string{"--broken-links"},
+			nil,
+			"Only show broken symbolic links",
+			"",
+		),
+		OwnerIs: newString(
+			[]string{"--owner-is"},
+			"",
+			"Only show items owned by given user (name or id)",
+		),
+		GroupIs: newString(
+			[]string{"--group-is"},
+			"",
+			"Only show items of given group (name or id)",
+		),
+		PermIs: newString(
+			[]string{"--perm-is"},
+			"",
+			"Only show items with permissions like find: MODE (exactly), -MODE (all bits of MODE) or /MODE (any bits of MODE); MODE can be octal like 644 or symbolic like u+x,g-w",
 		),
 
-		Dereference: goopt.Flag(
+		Dereference: newFlag(
 			[]string{"--dereference", "-L"},
 			nil,
 			"When showing file information for a symbolic link, show information for the file the link references rather than for the link itself",
 			"",
 		),
-		Links: goopt.Flag(
+		Links: newFlag(
 			[]string{"--links"},
 			nil,
 			"Show paths for symlinks",
 			"",
 		),
-		LinkRel: goopt.Flag(
+		LinkRel: newFlag(
 			[]string{"--link-rel"},
 			nil,
 			"Show symlinks as relative paths if shorter than absolute path",
 			"",
 		),
-		Reverse: goopt.Flag(
+		Reverse: newFlag(
 			[]string{"--reverse", "-r"},
 			nil,
 			"Reverse order while sorting",
 			"",
 		),
-		Stats: goopt.Flag(
+		Stats: newFlag(
 			[]string{"--stats"},
 			nil,
 			"Show statistics",
 			"",
 		),
-		Icons: goopt.Flag(
+		Summary: newFlag(
+			[]string{"--summary"},
+			nil,
+			"Show a summary after listing each directory: number of dirs and files, total size, newest and oldest modification time",
+			"",
+		),
+		Mounts: newFlag(
+			[]string{"--mounts"},
+			nil,
+			"Mark mount points, add a column with filesystem type and mount source, and show free/total space in folder headers",
+			"",
+		),
+		Icons: newFlag(
 			[]string{"--icons"},
 			nil,
 			"Show folder icon before directory name",
 			"",
 		),
-		Nerdfont: goopt.Flag(
+		Nerdfont: newFlag(
 			[]string{"--nerd-font"},
 			nil,
 			"Show nerd font glyphs before file names",
 			"",
 		),
-		Recursive: goopt.Flag(
+		Hyperlink: newAlternatives(
+			[]string{"--hyperlink"},
+			[]string{
+				"never", // default, must be first
+				"always", "auto",
+			},
+			"Make file names clickable file:// links (OSC 8 in terminal, <a> in html); 'auto' means if stdout connected to a terminal",
+		),
+		Recursive: newFlag(
 			[]string{
 				"--recursive", "-R",
 				// "--recurse",
@@ -422,12 +609,12 @@ func New() *Arguments {
 			"Traverse all directories recursively",
 			"",
 		),
-		Find: goopt.String(
+		Find: newString(
 			[]string{"--find"},
 			"",
 			"Filter items with a regexp",
 		),
-		Color: goopt.Alternatives(
+		Color: newAlternatives(
 			[]string{"--color"},
 			[]string{
 				"auto", // default, must be first
@@ -437,128 +624,234 @@ func New() *Arguments {
 			},
 			"Whether or not to colorize the output; 'auto' means if stdout connected to a terminal",
 		),
-		Header: goopt.Flag(
+		Background: newAlternatives(
+			[]string{"--background"},
+			[]string{
+				"auto", // default, must be first
+				"light", "dark", "query",
+			},
+			"Background color of terminal, to choose readable colors; 'auto' means detect from COLORFGBG; 'query' means detect from COLORFGBG, or by querying the terminal; Dark is assumed if detection fails",
+		),
+		ColorDepth: newAlternatives(
+			[]string{"--color-depth"},
+			[]string{
+				"auto", // default, must be first
+				"16", "256", "truecolor", "24bit",
+			},
+			"Number of colors supported by terminal; 'auto' means detect from COLORTERM, TERM and terminfo (256 unless terminal is known to be limited); Colors are converted to the nearest supported color",
+		),
+		Header: newFlag(
 			[]string{"--header"},
 			nil,
 			"Add a header line with '-l' / '--long' or '-1' / '--oneline'",
 			"",
 		),
-		NoHeader: goopt.Flag(
+		NoHeader: newFlag(
 			[]string{"--no-header"},
 			nil,
 			"Do not add a header line with '--csv' or '--json-array'",
 			"",
 		),
-		Json: goopt.Flag(
+		Json: newFlag(
 			[]string{"--json"},
 			nil,
 			"Print JSON-encoded lines instead of tables (one object per line)",
 			"",
 		),
-		JsonArray: goopt.Flag(
+		JsonArray: newFlag(
 			[]string{"--json-array"},
 			nil,
 			"Print JSON-encoded lines instead of tables, one array per line",
 			"",
 		),
-		ASCII: goopt.Flag(
+		ASCII: newFlag(
 			[]string{"--ascii"},
 			nil,
 			"With --json and --json-array, escape Unicode characters and ensure output is ASCII. In tabular/normal mode, apply this only to file names.",
 			"",
 		),
-		Csv: goopt.Flag(
+		Csv: newFlag(
 			[]string{"--csv"},
 			nil,
 			"Print a CSV table",
 			"",
 		),
-		Html: goopt.Flag(
+		Html: newFlag(
 			[]string{"--html"},
 			nil,
 			"Print HTML",
 			"",
 		),
 
-		ReadJson: goopt.Flag(
+		ReadJson: newFlag(
 			[]string{"--read-json"},
 			nil,
 			"Read JSON-encoded lines from stdin, instead of looking at filesystem and path arguments",
 			"",
 		),
 
-		Minsize: goopt.Int(
+		Minsize: newString(
 			[]string{"--minsize"},
-			0,
-			"minimum file size (in bytes)",
+			"",
+			"minimum file size, in bytes or with a unit like 10K, 5MB or 1GiB (same units as --block-size)",
 		),
-		Maxsize: goopt.Int(
+		Maxsize: newString(
 			[]string{"--maxsize"},
-			0,
-			"maximum file size (in bytes)",
+			"",
+			"maximum file size, in bytes or with a unit like 10K, 5MB or 1GiB (same units as --block-size)",
+		),
+
+		FilterTime: newAlternatives(
+			[]string{"--filter-time"},
+			[]string{
+				"mtime", "ctime", "atime", "btime", // main names
+				"status", "change", "access", "use", "birth", "creation",
+				"modified", "accessed", "created",
+			},
+			"Which time to use in --newer, --older, --newer-than, --older-than, --after and --before: mtime (default), ctime, atime or btime (birth time)",
+		),
+		Newer: newString(
+			[]string{"--newer"},
+			"",
+			"Only list files newer than given age, like 2h, 30d or 1w2d (units: s, m, h, d, w, mo, y)",
+		),
+		Older: newString(
+			[]string{"--older"},
+			"",
+			"Only list files older than given age, like 2h, 30d or 1w2d (units: s, m, h, d, w, mo, y)",
+		),
+		NewerThan: newString(
+			[]string{"--newer-than"},
+			"",
+			"Only list files newer than given file",
+		),
+		OlderThan: newString(
+			[]string{"--older-than"},
+			"",
+			"Only list files older than given file",
+		),
+		After: newString(
+			[]string{"--after", "--modified-after"},
+			"",
+			"Only list files modified (or see --filter-time) at or after given date, like 2026-01-01 or '2026-01-01 15:04'",
+		),
+		Before: newString(
+			[]string{"--before", "--modified-before"},
+			"",
+			"Only list files modified (or see --filter-time) before given date, like 2026-01-01 or '2026-01-01 15:04'",
 		),
 
-		Shortcut_t: goopt.Flag(
+		Shortcut_t: newFlag(
 			[]string{"-t"},
 			nil,
 			`Shortcut to --sort=time; Sort by time, newest first; See --time`,
 			"",
 		),
 
-		Shortcut_c: goopt.Flag(
+		Shortcut_c: newFlag(
 			[]string{"-c"},
 			nil,
 			`Shortcut to --time=ctime; With -lt: sort by, and show, ctime (time of last modification of file status information); With -l: show ctime and sort by name; Otherwise: sort by ctime, newest first`,
 			"",
 		),
 
-		Shortcut_u: goopt.Flag(
+		Shortcut_u: newFlag(
 			[]string{"-u"},
 			nil,
 			`Shortcut to --time=use; With -lt: sort by, and show, access time; With -l: show access time and sort by name; Otherwise: sort by access time, newest first`,
 			"",
 		),
 
-		Shortcut_U: goopt.Flag(
+		Shortcut_U: newFlag(
 			[]string{"-U"},
 			nil,
 			`Shortcut to --sort=none; Do not sort (list entries in directory order)`,
 			"",
 		),
 
-		Shortcut_S: goopt.Flag(
+		Shortcut_S: newFlag(
 			[]string{"-S"},
 			nil,
 			`Shortcut to --sort=size; Sort by file size, largest first`,
 			"",
 		),
 
-		Shortcut_X: goopt.Flag(
+		Shortcut_X: newFlag(
 			[]string{"-X"},
 			nil,
 			`Shortcut to --sort=extension; Sort alphabetically by entry extension`,
 			"",
 		),
 
-		ColorsJson: goopt.Flag(
+		Shortcut_v: newFlag(
+			[]string{"-v"},
+			nil,
+			`Shortcut to --sort=version; Natural sort of (version) numbers within names; With another sort column: sort items with equal values by version`,
+			"",
+		),
+
+		ColorsJson: newFlag(
 			[]string{"--colors-json"},
 			nil,
 			`Print colors in json format and exit`,
 			"",
 		),
 
-		CpuProfile: goopt.String(
+		LsColors: newFlag(
+			[]string{"--ls-colors"},
+			nil,
+			`Use colors from LSCOLORS (BSD), LS_COLORS (GNU dircolors) and EXA_COLORS environment variables, over the built-in colors`,
+			"",
+		),
+
+		Theme: newString(
+			[]string{"--theme"},
+			"",
+			"Color theme: default, solarized, high-contrast, colorblind-safe, monochrome-bold, or name or path of a theme file",
+		),
+		ThemePreview: newFlag(
+			[]string{"--theme-preview"},
+			nil,
+			"Print every color of the effective theme with a sample, and exit",
+			"",
+		),
+
+		ColorRule: newStrings(
+			[]string{"--color-rule"},
+			"RULE",
+			`Color rule like 'size > 1e9 => bold red, icon=X, row=on #303030', can be given more than once`,
+		),
+
+		CpuProfile: newString(
 			[]string{"--cpuprofile"},
 			"",
 			"Write cpu profile to file",
 		),
 
-		Expr: goopt.String(
-			[]string{"--expr"},
+		Profile: newString(
+			[]string{"--profile"},
 			"",
-			"An expression to be evaluated as a new column",
+			"Use given profile from config file, in addition to its defaults",
+		),
+		NoConfig: newFlag(
+			[]string{"--no-config"},
+			nil,
+			"Ignore config file",
+			"",
+		),
+		PrintConfig: newFlag(
+			[]string{"--print-config"},
+			nil,
+			"Print the effective config (flags, macros, icons and colors) with where each value came from, and exit",
+			"",
+		),
+
+		Expr: newStrings(
+			[]string{"--expr"},
+			"EXPR",
+			`An expression to be evaluated as a new column, like '[NAME[:TITLE][[OPTIONS]]=]EXPR', can be given more than once`,
 		),
-		Where: goopt.String(
+		Where: newString(
 			[]string{"--where"},
 			"",
 			"An expression to be evaluated and filter files by",
@@ -567,7 +860,7 @@ func New() *Arguments {
 }
 
 func (args *Arguments) Parse(rawArgs []string, version string) {
-	help_md := goopt.Flag([]string{"--help-md"}, nil, "show help in markdown format", "")
+	help_md := newFlag([]string{"--help-md"}, nil, "show help in markdown format", "")
 
 	// remove -h as Shortcut to --help, because we want -h as --human-readable
 	goopt.SetHelpFlags([]string{"--help"})
@@ -575,7 +868,7 @@ func (args *Arguments) Parse(rawArgs []string, version string) {
 	// set version for --version
 	goopt.Version = version
 
-	goopt.Parse(rawArgs, nil)
+	goopt.Parse(args.mergeConfig(rawArgs), nil)
 
 	if *help_md {
 		helpMarkdown()
@@ -0,0 +1,187 @@
+package lsargs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	goopt "github.com/ilius/goopt"
+	"github.com/ilius/ls-go/lsconfig"
+)
+
+// flagName returns the full name of given long flag, like goopt does:
+// any unique prefix is accepted. returns empty string if not known
+func flagName(arg string) string {
+	names := []string{"--help", "--help-md", "--version"}
+	goopt.VisitAllNames(func(name string) {
+		names = append(names, name)
+	})
+	result := ""
+	for _, name := range names {
+		if name == arg {
+			return name
+		}
+		if strings.HasPrefix(name, arg) {
+			if result != "" {
+				return ""
+			}
+			result = name
+		}
+	}
+	return result
+}
+
+// scanConfigFlags finds --profile and --no-config in command line, because
+// we need them before parsing (merged) arguments
+func scanConfigFlags(rawArgs []string) (profile string, noConfig bool) {
+	for i := 1; i < len(rawArgs); i++ {
+		arg := rawArgs[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+		nameArg, value, hasValue := strings.Cut(arg, "=")
+		switch flagName(nameArg) {
+		case "--profile":
+			if hasValue {
+				profile = value
+			} else if i+1 < len(rawArgs) {
+				profile = rawArgs[i+1]
+			}
+		case "--no-config":
+			noConfig = true
+		}
+	}
+	return
+}
+
+// applyBoolValues handles --flag=true and --flag=false for flags without
+// argument, which goopt does not accept: --flag=true is replaced with --flag
+// and --flag=false removes the flag from previous arguments (which may come
+// from config file or profile)
+func applyBoolValues(args []string) []string {
+	result := make([]string, 0, len(args))
+	for index, arg := range args {
+		if arg == "--" {
+			return append(result, args[index:]...)
+		}
+		nameArg, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue || !strings.HasPrefix(arg, "--") {
+			result = append(result, arg)
+			continue
+		}
+		info := lookupFlag(nameArg)
+		if info == nil || info.HasValue {
+			result = append(result, arg)
+			continue
+		}
+		enable, err := strconv.ParseBool(value)
+		if err != nil {
+			// goopt will report it
+			result = append(result, arg)
+			continue
+		}
+		if enable {
+			result = append(result, nameArg)
+			continue
+		}
+		result = removeFlag(result, info.Main)
+	}
+	return result
+}
+
+// removeFlag removes given flag (by main name) from args, including
+// its short name in combined short flags like -la
+func removeFlag(args []string, main string) []string {
+	result := args[:0]
+	for _, arg := range args {
+		if len(arg) < 2 || arg[0] != '-' {
+			result = append(result, arg)
+			continue
+		}
+		if arg[1] == '-' {
+			nameArg, _, _ := strings.Cut(arg, "=")
+			info := lookupFlag(nameArg)
+			if info != nil && info.Main == main {
+				continue
+			}
+			result = append(result, arg)
+			continue
+		}
+		shortArg := "-"
+		for _, char := range arg[1:] {
+			info := lookupFlag("-" + string(char))
+			if info != nil && info.Main == main {
+				continue
+			}
+			shortArg += string(char)
+		}
+		if shortArg != "-" {
+			result = append(result, shortArg)
+		}
+	}
+	return result
+}
+
+func fatalConfig(err error) {
+	fmt.Fprintln(os.Stderr, "config error:", err)
+	os.Exit(1)
+}
+
+// mergeConfig returns command line arguments with flags from config file
+// (defaults, then profile) inserted before the actual command line arguments
+// so that command line arguments override config file and profile
+func (args *Arguments) mergeConfig(rawArgs []string) []string {
+	profileName, noConfig := scanConfigFlags(rawArgs)
+	if noConfig && profileName != "" {
+		fatalConfig(fmt.Errorf("--profile and --no-config can not be used together"))
+	}
+	var conf *lsconfig.Config
+	path := ""
+	if !noConfig {
+		path = lsconfig.DefaultPath()
+		var err error
+		conf, err = lsconfig.Load(path)
+		if err != nil {
+			fatalConfig(err)
+		}
+		if conf == nil {
+			path = ""
+		}
+	}
+	effective := lsconfig.NewEffective(path, profileName)
+	args.Config = effective
+
+	merged := []string{rawArgs[0]}
+	if conf != nil {
+		flagArgs, err := effective.AddSettings(&conf.Settings, lsconfig.SourceConfigFile, lookupFlag)
+		if err != nil {
+			fatalConfig(err)
+		}
+		merged = append(merged, flagArgs...)
+	}
+	if profileName != "" {
+		if conf == nil {
+			fatalConfig(fmt.Errorf("profile %#v given, but there is no config file", profileName))
+		}
+		profile, err := conf.Profile(profileName)
+		if err != nil {
+			fatalConfig(err)
+		}
+		flagArgs, err := effective.AddSettings(
+			profile,
+			lsconfig.ProfileSource(profileName),
+			lookupFlag,
+		)
+		if err != nil {
+			fatalConfig(err)
+		}
+		merged = append(merged, flagArgs...)
+	}
+	effective.AddArgs(rawArgs[1:], lsconfig.SourceCommandLine, lookupFlag)
+	merged = append(merged, rawArgs[1:]...)
+	return append(merged[:1], applyBoolValues(merged[1:])...)
+}
@@ -0,0 +1,31 @@
+package lsargs
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestApplyBoolValues(t *testing.T) {
+	is := is.New(t)
+	New()
+	test := func(args []string, expected []string) {
+		is.AddMsg("args=%#v", args).Equal(applyBoolValues(args), expected)
+	}
+	test(
+		[]string{"--long", "--sort=size", "--icons=false", "--long=false"},
+		[]string{"--sort=size"},
+	)
+	test(
+		[]string{"--long", "-la", "--lo=false", "--sort", "name"},
+		[]string{"-a", "--sort", "name"},
+	)
+	test(
+		[]string{"--icons=true", "--long=no", "--", "--long=false"},
+		[]string{"--icons", "--long=no", "--", "--long=false"},
+	)
+	test(
+		[]string{"-l", "--perm", "--mode=false"},
+		[]string{"-l"},
+	)
+}
@@ -0,0 +1,94 @@
+package lsargs
+
+import (
+	"strings"
+
+	goopt "github.com/ilius/goopt"
+	"github.com/ilius/ls-go/lsconfig"
+)
+
+// flagInfoByName has info of all flags by each of their names (long and
+// short), because goopt does not tell us if a flag takes a value
+var flagInfoByName = map[string]*lsconfig.FlagInfo{}
+
+// shortcutTargets maps main name of shortcut flags to the flag they set
+// -v is not here, because it does not override --sort
+var shortcutTargets = map[string]string{
+	"-t":          "--sort",
+	"-U":          "--sort",
+	"-S":          "--sort",
+	"-X":          "--sort",
+	"-c":          "--time",
+	"-u":          "--time",
+	"--literal":   "--quoting-style",
+	"--escape":    "--quoting-style",
+	"--classify":  "--indicator-style",
+	"--file-type": "--indicator-style",
+	"-p":          "--indicator-style",
+}
+
+// addFlagInfo records names of a flag, main name is the first long name
+func addFlagInfo(names []string, hasValue bool) {
+	if len(names) == 0 {
+		return
+	}
+	main := names[0]
+	for _, name := range names {
+		if strings.HasPrefix(name, "--") {
+			main = name
+			break
+		}
+	}
+	for _, name := range names {
+		flagInfoByName[name] = &lsconfig.FlagInfo{
+			Name:     name,
+			Main:     main,
+			Target:   shortcutTargets[main],
+			HasValue: hasValue,
+		}
+	}
+}
+
+// lookupFlag returns info of given flag (without value), or nil if
+// not known. like goopt, any unique prefix of a long flag is accepted
+func lookupFlag(arg string) *lsconfig.FlagInfo {
+	if !strings.HasPrefix(arg, "--") {
+		return flagInfoByName[arg]
+	}
+	name := flagName(arg)
+	if name == "" {
+		return nil
+	}
+	info := flagInfoByName[name]
+	if info == nil {
+		// --help and --version
+		return &lsconfig.FlagInfo{Name: name, Main: name}
+	}
+	return info
+}
+
+func newFlag(yes []string, no []string, helpyes string, helpno string) *bool {
+	addFlagInfo(yes, false)
+	addFlagInfo(no, false)
+	return goopt.Flag(yes, no, helpyes, helpno)
+}
+
+func newString(names []string, def string, help string) *string {
+	addFlagInfo(names, true)
+	return goopt.String(names, def, help)
+}
+
+func newStrings(names []string, def string, help string) *[]string {
+	addFlagInfo(names, true)
+	return goopt.Strings(names, def, help)
+}
+
+func newInt(names []string, def int, help string) *int {
+	addFlagInfo(names, true)
+	return goopt.Int(names, def, help)
+}
+
+func newAlternatives(names []string, values []string, help string) *string {
+	addFlagInfo(names, true)
+	return goopt.Alternatives(names, values, help)
+}
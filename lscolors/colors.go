@@ -1,8 +1,9 @@
 package lscolors
 
 import (
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 )
 
 // see the color codes
@@ -17,15 +18,90 @@ type Style struct {
 	Fg   uint8 `json:"fg,omitempty"`
 	Bg   uint8 `json:"bg,omitempty"`
 	Bold bool  `json:"bold,omitempty"`
+
+	// 24-bit colors, used instead of Fg and Bg if set (and supported by
+	// terminal), in JSON "fg" and "bg" can also be given as "#rrggbb"
+	FgRGB *RGB `json:"fg_rgb,omitempty"`
+	BgRGB *RGB `json:"bg_rgb,omitempty"`
+
+	Dim       bool `json:"dim,omitempty"`
+	Italic    bool `json:"italic,omitempty"`
+	Underline bool `json:"underline,omitempty"`
+	Reverse   bool `json:"reverse,omitempty"`
+	Strike    bool `json:"strike,omitempty"`
+}
+
+// parseColorJSON parses a color which is either a 256-color code or a
+// "#rrggbb" string
+func parseColorJSON(data json.RawMessage) (uint8, *RGB, error) {
+	var code uint8
+	err := json.Unmarshal(data, &code)
+	if err == nil {
+		return code, nil, nil
+	}
+	var hex string
+	err = json.Unmarshal(data, &hex)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid color %s, must be 0-255 or \"#rrggbb\"", string(data))
+	}
+	rgb, err := ParseHex(strings.ToLower(hex))
+	if err != nil {
+		return 0, nil, err
+	}
+	return Nearest256(*rgb), rgb, nil
+}
+
+func (s *Style) UnmarshalJSON(data []byte) error {
+	type styleAlias Style
+	aux := struct {
+		*styleAlias
+		Fg    json.RawMessage `json:"fg"`
+		Bg    json.RawMessage `json:"bg"`
+		FgRGB *RGB            `json:"fg_rgb"`
+		BgRGB *RGB            `json:"bg_rgb"`
+	}{
+		styleAlias: (*styleAlias)(s),
+	}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	if aux.Fg != nil {
+		s.Fg, s.FgRGB, err = parseColorJSON(aux.Fg)
+		if err != nil {
+			return err
+		}
+	}
+	if aux.Bg != nil {
+		s.Bg, s.BgRGB, err = parseColorJSON(aux.Bg)
+		if err != nil {
+			return err
+		}
+	}
+	if aux.FgRGB != nil {
+		s.FgRGB = aux.FgRGB
+		if aux.Fg == nil {
+			s.Fg = Nearest256(*aux.FgRGB)
+		}
+	}
+	if aux.BgRGB != nil {
+		s.BgRGB = aux.BgRGB
+		if aux.Bg == nil {
+			s.Bg = Nearest256(*aux.BgRGB)
+		}
+	}
+	return nil
 }
 
 func (s *Style) SetFg(code uint8) *Style {
 	s.Fg = code
+	s.FgRGB = nil
 	return s
 }
 
 func (s *Style) SetBg(code uint8) *Style {
 	s.Bg = code
+	s.BgRGB = nil
 	return s
 }
 
@@ -34,19 +110,62 @@ func (s *Style) SetBold() *Style {
 	return s
 }
 
+// FgHex returns foreground color in "#rrggbb" format, or empty string
+func (s *Style) FgHex() string {
+	if s.FgRGB != nil {
+		return s.FgRGB.Hex()
+	}
+	if s.Fg > 0 {
+		return TermColorsHex[s.Fg]
+	}
+	return ""
+}
+
+// BgHex returns background color in "#rrggbb" format, or empty string
+func (s *Style) BgHex() string {
+	if s.BgRGB != nil {
+		return s.BgRGB.Hex()
+	}
+	if s.Bg > 0 {
+		return TermColorsHex[s.Bg]
+	}
+	return ""
+}
+
+// S returns the ANSI escape sequence for Style, with color depth of Depth
 func (s *Style) S() string {
+	return s.SDepth(Depth)
+}
+
+// SDepth returns the ANSI escape sequence for Style, with given color depth
+func (s *Style) SDepth(depth ColorDepth) string {
 	st := ""
 	// color code 0 is black, but code 16 is also black
 	// besides we never set color black as fg or bg
-	if s.Fg > 0 {
-		st += "\x1b[38;5;" + strconv.FormatUint(uint64(s.Fg), 10) + "m"
+	if params := colorParams(s.Fg, s.FgRGB, depth, false); params != "" {
+		st += "\x1b[" + params + "m"
 	}
-	if s.Bg > 0 {
-		st += "\x1b[48;5;" + strconv.FormatUint(uint64(s.Bg), 10) + "m"
+	if params := colorParams(s.Bg, s.BgRGB, depth, true); params != "" {
+		st += "\x1b[" + params + "m"
 	}
 	if s.Bold {
 		st += "\x1b[1m"
 	}
+	if s.Dim {
+		st += "\x1b[2m"
+	}
+	if s.Italic {
+		st += "\x1b[3m"
+	}
+	if s.Underline {
+		st += "\x1b[4m"
+	}
+	if s.Reverse {
+		st += "\x1b[7m"
+	}
+	if s.Strike {
+		st += "\x1b[9m"
+	}
 	return st
 }
 
@@ -80,6 +199,26 @@ func Bg(code uint8) *Style {
 	return &Style{Bg: code}
 }
 
+// RGBFg returns a style with 24-bit foreground color given as "#rrggbb"
+func RGBFg(hex string) *Style {
+	rgb, err := ParseHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	return &Style{Fg: Nearest256(*rgb), FgRGB: rgb}
+}
+
+// SetBgHex sets 24-bit background color given as "#rrggbb"
+func (s *Style) SetBgHex(hex string) *Style {
+	rgb, err := ParseHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	s.Bg = Nearest256(*rgb)
+	s.BgRGB = rgb
+	return s
+}
+
 // Rgb2code converts RGB values (up to 5) to an 8-bit color code
 /*
 func Rgb2code(r uint8, g uint8, b uint8) uint8 {
@@ -110,6 +249,12 @@ func BgGray(lightness uint8) *Style {
 	return Bg(Gray(lightness))
 }
 
+// LightBackground is true if terminal background is light
+var LightBackground = false
+
+// LightDark is a pair of lighter and darker shades of a color
+// the one with more contrast to background is used as main color (for name)
+// and the other one as accent color (for extension)
 type LightDark struct {
 	Light *Style `json:"light"`
 	Dark  *Style `json:"dark"`
@@ -117,6 +262,9 @@ type LightDark struct {
 
 // returns (mainColor, accentColor)
 func (ld LightDark) Get() (*Style, *Style) {
+	if LightBackground {
+		return ld.Dark, ld.Light
+	}
 	return ld.Light, ld.Dark
 }
 
@@ -125,6 +273,12 @@ type DirColors struct {
 	Ext        *Style `json:"ext"`
 	HiddenName *Style `json:"hidden_name"`
 	HiddenExt  *Style `json:"hidden_ext"`
+
+	// for directories with sticky bit and/or writable by others
+	// nil means same as Name
+	Sticky              *Style `json:"sticky"`
+	OtherWritable       *Style `json:"other_writable"`
+	StickyOtherWritable *Style `json:"sticky_other_writable"`
 }
 
 type LinkColors struct {
@@ -133,6 +287,11 @@ type LinkColors struct {
 	Arrow   *Style `json:"arrow"`
 	Path    *Style `json:"path"`
 	Broken  *Style `json:"broken"`
+	// name of broken (orphan) link, nil means same as Name
+	Orphan *Style `json:"orphan"`
+	// Target is true to color name of link like its target (ignoring Name
+	// and NameDir), from "ln=target" in LS_COLORS
+	Target bool `json:"target"`
 }
 
 type TimeColors struct {
@@ -141,6 +300,10 @@ type TimeColors struct {
 	NumberColon *Style `json:"number_colon"`
 	NumberSlash *Style `json:"number_slash"`
 	Word        *Style `json:"word"`
+
+	// Age is for relative times (--time-style=relative), by keys:
+	// future, minute, hour, day, week, month and year
+	Age StyleMap `json:"age"`
 }
 
 // PermColor holds color mappings for users and groups
@@ -156,6 +319,19 @@ type FolderHeaderColors struct {
 	Slash      *Style `json:"slash"`
 	LastFolder *Style `json:"last_folder"`
 	Error      *Style `json:"error"`
+	Space      *Style `json:"space"`
+}
+
+type GroupHeaderColors struct {
+	Arrow   *Style `json:"arrow"`
+	Label   *Style `json:"label"`
+	Summary *Style `json:"summary"`
+}
+
+type MountColors struct {
+	Marker *Style `json:"marker"`
+	FsType *Style `json:"fs_type"`
+	Source *Style `json:"source"`
 }
 
 type StatsColors struct {
@@ -175,13 +351,17 @@ type ExprColors struct {
 
 type TabularColors struct {
 	FolderHeader FolderHeaderColors `json:"folder_header"`
+	GroupHeader  GroupHeaderColors  `json:"group_header"`
 	TableHeader  *Style             `json:"table_header"`
+	Summary      *Style             `json:"summary"`
 }
 
 type HtmlColors struct {
 	Default      *Style             `json:"default"`
 	FolderHeader FolderHeaderColors `json:"folder_header"`
+	GroupHeader  GroupHeaderColors  `json:"group_header"`
 	TableHeader  *Style             `json:"table_header"`
+	Summary      *Style             `json:"summary"`
 }
 
 type Colors struct {
@@ -200,5 +380,19 @@ type Colors struct {
 	Socket *Style `json:"socket"`
 	Pipe   *Style `json:"pipe"`
 
+	// nil means same as Device
+	CharDevice *Style `json:"char_device"`
+
+	// for executable, setuid and setgid files, nil means color by extension
+	Exec   *Style `json:"exec"`
+	Setuid *Style `json:"setuid"`
+	Setgid *Style `json:"setgid"`
+
+	// checked before File (by extension)
+	Suffix []*SuffixStyle `json:"suffix"`
+
+	Mount     MountColors `json:"mount"`
+	LinkGroup *Style      `json:"link_group"`
+
 	Stats StatsColors `json:"stats"`
 }
@@ -0,0 +1,249 @@
+package lscolors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColorDepth is the number of colors supported by terminal
+type ColorDepth int
+
+const (
+	Depth16   ColorDepth = 16
+	Depth256  ColorDepth = 256
+	DepthTrue ColorDepth = 1 << 24
+)
+
+// Depth is the color depth used by Style.S
+var Depth = Depth256
+
+// ParseColorDepth parses value of --color-depth (except "auto")
+func ParseColorDepth(value string) (ColorDepth, error) {
+	switch value {
+	case "16", "8":
+		return Depth16, nil
+	case "256":
+		return Depth256, nil
+	case "truecolor", "24bit":
+		return DepthTrue, nil
+	}
+	return 0, fmt.Errorf("invalid color depth %#v", value)
+}
+
+func (d ColorDepth) String() string {
+	switch d {
+	case Depth16:
+		return "16"
+	case Depth256:
+		return "256"
+	case DepthTrue:
+		return "truecolor"
+	}
+	return strconv.Itoa(int(d))
+}
+
+// RGB is a 24-bit color, encoded as "#rrggbb" in JSON
+type RGB struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+// ParseHex parses a color in "#rrggbb" format
+func ParseHex(hex string) (*RGB, error) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return nil, fmt.Errorf("invalid color %#v, must be #rrggbb", hex)
+	}
+	n, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %#v, must be #rrggbb", hex)
+	}
+	return &RGB{
+		R: uint8(n >> 16),
+		G: uint8(n >> 8),
+		B: uint8(n),
+	}, nil
+}
+
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (c RGB) MarshalText() ([]byte, error) {
+	return []byte(c.Hex()), nil
+}
+
+func (c *RGB) UnmarshalText(text []byte) error {
+	parsed, err := ParseHex(strings.ToLower(string(text)))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+var termColorsRGB = func() [256]RGB {
+	result := [256]RGB{}
+	for i, hex := range TermColorsHex {
+		rgb, err := ParseHex(hex)
+		if err != nil {
+			panic(err)
+		}
+		result[i] = *rgb
+	}
+	return result
+}()
+
+func colorDistance(a RGB, b RGB) int {
+	dr := int(a.R) - int(b.R)
+	dg := int(a.G) - int(b.G)
+	db := int(a.B) - int(b.B)
+	// weighted for human perception
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
+
+// nearestColor returns index of nearest color to c in TermColorsHex,
+// among indexes start to end-1
+func nearestColor(c RGB, start int, end int) uint8 {
+	best := start
+	bestDist := -1
+	for i := start; i < end; i++ {
+		dist := colorDistance(c, termColorsRGB[i])
+		if bestDist < 0 || dist < bestDist {
+			best = i
+			bestDist = dist
+		}
+	}
+	return uint8(best)
+}
+
+// Nearest256 returns the nearest 256-color code to c (excluding the first
+// 16 colors, which depend on terminal theme)
+func Nearest256(c RGB) uint8 {
+	return nearestColor(c, 16, 256)
+}
+
+// Nearest16 returns the nearest 16-color code to c
+func Nearest16(c RGB) uint8 {
+	return nearestColor(c, 0, 16)
+}
+
+// NearestInPalette returns the nearest color to c among given palette
+func NearestInPalette(c RGB, palette []RGB) RGB {
+	best := palette[0]
+	bestDist := colorDistance(c, best)
+	for _, color := range palette[1:] {
+		dist := colorDistance(c, color)
+		if dist < bestDist {
+			best = color
+			bestDist = dist
+		}
+	}
+	return best
+}
+
+// FgColor returns foreground color as RGB, or nil if not set
+func (s *Style) FgColor() *RGB {
+	if s.FgRGB != nil {
+		return s.FgRGB
+	}
+	if s.Fg > 0 {
+		return &termColorsRGB[s.Fg]
+	}
+	return nil
+}
+
+// BgColor returns background color as RGB, or nil if not set
+func (s *Style) BgColor() *RGB {
+	if s.BgRGB != nil {
+		return s.BgRGB
+	}
+	if s.Bg > 0 {
+		return &termColorsRGB[s.Bg]
+	}
+	return nil
+}
+
+// ToPalette returns a copy of style with colors replaced by nearest colors
+// in given palette
+func (s *Style) ToPalette(palette []RGB) *Style {
+	result := *s
+	if fg := s.FgColor(); fg != nil {
+		rgb := NearestInPalette(*fg, palette)
+		result.Fg = Nearest256(rgb)
+		result.FgRGB = &rgb
+	}
+	if bg := s.BgColor(); bg != nil {
+		rgb := NearestInPalette(*bg, palette)
+		result.Bg = Nearest256(rgb)
+		result.BgRGB = &rgb
+	}
+	return &result
+}
+
+// Describe returns a human-readable description of style, like
+// "fg=208 (#ff8700) bold"
+func (s *Style) Describe() string {
+	parts := []string{}
+	if hex := s.FgHex(); hex != "" {
+		parts = append(parts, fmt.Sprintf("fg=%d (%s)", s.Fg, hex))
+	}
+	if hex := s.BgHex(); hex != "" {
+		parts = append(parts, fmt.Sprintf("bg=%d (%s)", s.Bg, hex))
+	}
+	attrs := []struct {
+		set  bool
+		name string
+	}{
+		{s.Bold, "bold"},
+		{s.Dim, "dim"},
+		{s.Italic, "italic"},
+		{s.Underline, "underline"},
+		{s.Reverse, "reverse"},
+		{s.Strike, "strike"},
+	}
+	for _, attr := range attrs {
+		if attr.set {
+			parts = append(parts, attr.name)
+		}
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, " ")
+}
+
+// colorParams returns SGR parameters for given color (code or rgb), or empty
+// string if no color is set
+func colorParams(code uint8, rgb *RGB, depth ColorDepth, bg bool) string {
+	ext := "38"
+	base := 30
+	if bg {
+		ext = "48"
+		base = 40
+	}
+	if rgb == nil && code == 0 {
+		return ""
+	}
+	switch depth {
+	case DepthTrue:
+		if rgb != nil {
+			return fmt.Sprintf("%s;2;%d;%d;%d", ext, rgb.R, rgb.G, rgb.B)
+		}
+	case Depth16:
+		if rgb != nil {
+			code = Nearest16(*rgb)
+		} else if code >= 16 {
+			code = Nearest16(termColorsRGB[code])
+		}
+		if code < 8 {
+			return strconv.Itoa(base + int(code))
+		}
+		return strconv.Itoa(base + 60 + int(code) - 8)
+	}
+	if rgb != nil {
+		code = Nearest256(*rgb)
+	}
+	return ext + ";5;" + strconv.FormatUint(uint64(code), 10)
+}
@@ -0,0 +1,66 @@
+package lscolors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestStyleSDepth(t *testing.T) {
+	is := is.New(t)
+	style := &Style{Fg: 208, Bg: 16, Bold: true}
+	is.Equal(style.SDepth(Depth256), "\x1b[38;5;208m\x1b[48;5;16m\x1b[1m")
+	is.Equal(style.SDepth(DepthTrue), "\x1b[38;5;208m\x1b[48;5;16m\x1b[1m")
+	is.Equal(style.SDepth(Depth16), "\x1b[33m\x1b[40m\x1b[1m")
+
+	style = &Style{Fg: 208, FgRGB: &RGB{255, 136, 1}, Underline: true, Italic: true}
+	is.Equal(style.SDepth(DepthTrue), "\x1b[38;2;255;136;1m\x1b[3m\x1b[4m")
+	is.Equal(style.SDepth(Depth256), "\x1b[38;5;208m\x1b[3m\x1b[4m")
+	is.Equal(style.SDepth(Depth16), "\x1b[33m\x1b[3m\x1b[4m")
+
+	is.Equal((&Style{Fg: 4, Bg: 12}).SDepth(Depth16), "\x1b[34m\x1b[104m")
+	is.Equal((&Style{}).SDepth(Depth16), "")
+}
+
+func TestNearest(t *testing.T) {
+	is := is.New(t)
+	is.Equal(Nearest256(RGB{255, 135, 0}), uint8(208))
+	is.Equal(Nearest256(RGB{0, 0, 0}), uint8(16))
+	is.Equal(Nearest256(RGB{0x80, 0x80, 0x80}), uint8(244))
+	is.Equal(Nearest16(RGB{250, 250, 250}), uint8(15))
+	is.Equal(Nearest16(RGB{0, 0, 160}), uint8(4))
+}
+
+func TestStyleUnmarshalJSON(t *testing.T) {
+	is := is.New(t)
+	test := func(jsonStr string, expected *Style) {
+		style := &Style{Fg: 1, Bold: true}
+		err := json.Unmarshal([]byte(jsonStr), style)
+		is.AddMsg("json=%s", jsonStr).NotErr(err)
+		is.AddMsg("json=%s", jsonStr).Equal(style, expected)
+	}
+	test(`{}`, &Style{Fg: 1, Bold: true})
+	test(`{"fg": 33}`, &Style{Fg: 33, Bold: true})
+	test(`{"fg": "#FF8700", "dim": true}`, &Style{
+		Fg:    208,
+		FgRGB: &RGB{255, 135, 0},
+		Bold:  true,
+		Dim:   true,
+	})
+	test(`{"bg_rgb": "#000000", "bold": false}`, &Style{
+		Fg:    1,
+		Bg:    16,
+		BgRGB: &RGB{0, 0, 0},
+	})
+
+	style := &Style{}
+	err := json.Unmarshal([]byte(`{"fg": "red"}`), style)
+	is.ErrMsg(err, `invalid color "red", must be #rrggbb`)
+	err = json.Unmarshal([]byte(`{"fg": 300}`), style)
+	is.ErrMsg(err, `invalid color 300, must be 0-255 or "#rrggbb"`)
+
+	data, err := json.Marshal(&Style{Fg: 208, FgRGB: &RGB{255, 135, 0}})
+	is.NotErr(err)
+	is.Equal(string(data), `{"fg":208,"fg_rgb":"#ff8700"}`)
+}
@@ -0,0 +1,309 @@
+package lscolors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SuffixStyle is the color for files with names ending with Suffix
+// (case-insensitive), from LS_COLORS patterns like "*~" or "*.tar.gz"
+type SuffixStyle struct {
+	Suffix string `json:"suffix"`
+	Style  *Style `json:"style"`
+}
+
+// ParseSGR converts SGR parameters of an ANSI escape sequence
+// (like "01;38;5;33") to a Style
+// blink, hidden and other rarely used attributes are ignored
+func ParseSGR(sgr string) (*Style, error) {
+	style := &Style{}
+	if sgr == "" {
+		return style, nil
+	}
+	parts := strings.Split(sgr, ";")
+	codes := make([]uint8, len(parts))
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		code, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SGR code %#v in %#v", part, sgr)
+		}
+		codes[i] = uint8(code)
+	}
+	// extended color, after 38 or 48
+	extColor := func(i int) (uint8, *RGB, int, error) {
+		if i+2 < len(codes) && codes[i+1] == 5 {
+			return codes[i+2], nil, i + 2, nil
+		}
+		if i+4 < len(codes) && codes[i+1] == 2 {
+			rgb := &RGB{R: codes[i+2], G: codes[i+3], B: codes[i+4]}
+			return Nearest256(*rgb), rgb, i + 4, nil
+		}
+		return 0, nil, i, fmt.Errorf("invalid extended color in %#v", sgr)
+	}
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			*style = Style{}
+		case code == 1:
+			style.Bold = true
+		case code == 2:
+			style.Dim = true
+		case code == 3:
+			style.Italic = true
+		case code == 4:
+			style.Underline = true
+		case code == 7:
+			style.Reverse = true
+		case code == 9:
+			style.Strike = true
+		case code == 22:
+			style.Bold = false
+			style.Dim = false
+		case code == 23:
+			style.Italic = false
+		case code == 24:
+			style.Underline = false
+		case code == 27:
+			style.Reverse = false
+		case code == 29:
+			style.Strike = false
+		// color code 0 means no color in Style, so we use 16 for black
+		case code == 30:
+			style.SetFg(16)
+		case code > 30 && code <= 37:
+			style.SetFg(code - 30)
+		case code == 39:
+			style.SetFg(0)
+		case code == 40:
+			style.SetBg(16)
+		case code > 40 && code <= 47:
+			style.SetBg(code - 40)
+		case code == 49:
+			style.SetBg(0)
+		case code >= 90 && code <= 97:
+			style.SetFg(code - 90 + 8)
+		case code >= 100 && code <= 107:
+			style.SetBg(code - 100 + 8)
+		case code == 38, code == 48:
+			color, rgb, next, err := extColor(i)
+			if err != nil {
+				return nil, err
+			}
+			if color == 0 {
+				color = 16
+			}
+			if code == 38 {
+				style.SetFg(color).FgRGB = rgb
+			} else {
+				style.SetBg(color).BgRGB = rgb
+			}
+			i = next
+		}
+	}
+	return style, nil
+}
+
+// ApplyLSColors applies colors in format of GNU LS_COLORS (generated by
+// dircolors), like "di=01;34:ln=01;36:*.tar=01;31"
+// unsupported keys (like "rs" or "ca") are ignored, and invalid entries
+// are skipped and returned as error, after applying the valid ones
+func (c *Colors) ApplyLSColors(value string) error {
+	return c.applyLSColorsEntries(value, nil)
+}
+
+// ApplyExaColors applies colors in format of EXA_COLORS (or EZA_COLORS),
+// which is LS_COLORS format with some more keys, like "da" for date
+// and "sn" for size
+func (c *Colors) ApplyExaColors(value string) error {
+	return c.applyLSColorsEntries(value, c.exaStyleSetters())
+}
+
+type styleSetter func(style *Style)
+
+func (c *Colors) lsStyleSetters() map[string]styleSetter {
+	return map[string]styleSetter{
+		"fi": func(style *Style) {
+			if c.File == nil {
+				c.File = LightDarkMap{}
+			}
+			c.File[DEFAULT] = LightDark{Light: style, Dark: style}
+		},
+		"di": func(style *Style) {
+			c.Dir.Name = style
+			c.Dir.HiddenName = style
+			c.Dir.Ext = style
+			c.Dir.HiddenExt = style
+		},
+		"ln": func(style *Style) {
+			c.Link.Name = style
+			c.Link.NameDir = style
+			c.Link.Target = false
+		},
+		"or": func(style *Style) { c.Link.Orphan = style },
+		"mi": func(style *Style) { c.Link.Broken = style },
+		"ex": func(style *Style) { c.Exec = style },
+		"su": func(style *Style) { c.Setuid = style },
+		"sg": func(style *Style) { c.Setgid = style },
+		"tw": func(style *Style) { c.Dir.StickyOtherWritable = style },
+		"ow": func(style *Style) { c.Dir.OtherWritable = style },
+		"st": func(style *Style) { c.Dir.Sticky = style },
+		"pi": func(style *Style) { c.Pipe = style },
+		"so": func(style *Style) { c.Socket = style },
+		"bd": func(style *Style) { c.Device = style },
+		"cd": func(style *Style) { c.CharDevice = style },
+	}
+}
+
+func (c *Colors) exaStyleSetters() map[string]styleSetter {
+	return map[string]styleSetter{
+		"da": func(style *Style) {
+			c.Time = TimeColors{
+				Year:        style,
+				Number:      style,
+				NumberColon: style,
+				NumberSlash: style,
+				Word:        style,
+			}
+		},
+		"sn": func(style *Style) {
+			for key := range c.Size {
+				c.Size[key] = style
+			}
+		},
+		"lp": func(style *Style) { c.Link.Path = style },
+		"uu": func(style *Style) { c.Perm.User[SELF] = style },
+		"un": func(style *Style) { c.Perm.User[DEFAULT] = style },
+		"gn": func(style *Style) { c.Perm.Group[DEFAULT] = style },
+	}
+}
+
+func (c *Colors) applyLSColorsEntries(value string, extra map[string]styleSetter) error {
+	setters := c.lsStyleSetters()
+	for key, setter := range extra {
+		setters[key] = setter
+	}
+	errs := []error{}
+	for _, entry := range strings.Split(value, ":") {
+		if entry == "" {
+			continue
+		}
+		key, sgr, ok := strings.Cut(entry, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid entry %#v, must be KEY=VALUE", entry))
+			continue
+		}
+		if key == "ln" && sgr == "target" {
+			// color links like their targets
+			c.Link.Target = true
+			continue
+		}
+		style, err := ParseSGR(sgr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("entry %#v: %w", entry, err))
+			continue
+		}
+		if strings.HasPrefix(key, "*") {
+			c.setSuffixStyle(key[1:], style)
+			continue
+		}
+		setter := setters[key]
+		if setter == nil {
+			continue
+		}
+		setter(style)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Colors) setSuffixStyle(suffix string, style *Style) {
+	suffix = strings.ToLower(suffix)
+	ext := strings.TrimPrefix(suffix, ".")
+	if len(ext) < len(suffix) && ext != "" && !strings.Contains(ext, ".") {
+		if c.File == nil {
+			c.File = LightDarkMap{}
+		}
+		c.File[ext] = LightDark{Light: style, Dark: style}
+		return
+	}
+	for _, item := range c.Suffix {
+		if item.Suffix == suffix {
+			item.Style = style
+			return
+		}
+	}
+	c.Suffix = append(c.Suffix, &SuffixStyle{Suffix: suffix, Style: style})
+}
+
+// GetSuffixStyle returns the style for the longest suffix matching name
+// (case-insensitive), or nil if no suffix matches
+func (c *Colors) GetSuffixStyle(name string) *Style {
+	if len(c.Suffix) == 0 {
+		return nil
+	}
+	name = strings.ToLower(name)
+	var result *SuffixStyle
+	for _, item := range c.Suffix {
+		if !strings.HasSuffix(name, item.Suffix) {
+			continue
+		}
+		if result == nil || len(item.Suffix) > len(result.Suffix) {
+			result = item
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	return result.Style
+}
+
+// BSD LSCOLORS: pairs of foreground and background letters, in this order
+var bsdLSColorsKeys = []string{
+	"di", "ln", "so", "pi", "ex", "bd", "cd", "su", "sg", "tw", "ow",
+}
+
+func bsdColor(letter byte) (color uint8, bold bool, err error) {
+	switch {
+	case letter == 'x':
+		return 0, false, nil
+	case letter == 'a':
+		return 16, false, nil
+	case letter > 'a' && letter <= 'h':
+		return letter - 'a', false, nil
+	case letter == 'A':
+		return 16, true, nil
+	case letter > 'A' && letter <= 'H':
+		return letter - 'A', true, nil
+	}
+	return 0, false, fmt.Errorf("invalid color letter %#v", string(letter))
+}
+
+// ApplyBSDLSColors applies colors in format of BSD LSCOLORS,
+// like "exfxcxdxbxegedabagacad"
+func (c *Colors) ApplyBSDLSColors(value string) error {
+	if len(value)%2 != 0 || len(value) > 2*len(bsdLSColorsKeys) {
+		return fmt.Errorf("invalid LSCOLORS %#v", value)
+	}
+	setters := c.lsStyleSetters()
+	for i := 0; i < len(value); i += 2 {
+		fg, bold, err := bsdColor(value[i])
+		if err != nil {
+			return err
+		}
+		bg, _, err := bsdColor(value[i+1])
+		if err != nil {
+			return err
+		}
+		setters[bsdLSColorsKeys[i/2]](&Style{
+			Fg:   fg,
+			Bg:   bg,
+			Bold: bold,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package lscolors
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseSGR(t *testing.T) {
+	is := is.New(t)
+	test := func(sgr string, expected *Style) {
+		style, err := ParseSGR(sgr)
+		is.AddMsg("sgr=%#v", sgr).NotErr(err)
+		is.AddMsg("sgr=%#v", sgr).Equal(style, expected)
+	}
+	test("", &Style{})
+	test("01;34", &Style{Fg: 4, Bold: true})
+	test("30;42", &Style{Fg: 16, Bg: 2})
+	test("90;107", &Style{Fg: 8, Bg: 15})
+	test("38;5;208;48;5;0", &Style{Fg: 208, Bg: 16})
+	test("38;2;255;135;0", &Style{Fg: 208, FgRGB: &RGB{255, 135, 0}})
+	test("04;01;31", &Style{Fg: 1, Bold: true, Underline: true})
+	test("02;03;07;09;23", &Style{Dim: true, Reverse: true, Strike: true})
+	test("01;31;00;32", &Style{Fg: 2})
+
+	_, err := ParseSGR("01;x")
+	is.ErrMsg(err, `invalid SGR code "x" in "01;x"`)
+	_, err = ParseSGR("38;5")
+	is.ErrMsg(err, `invalid extended color in "38;5"`)
+}
+
+func TestApplyLSColors(t *testing.T) {
+	is := is.New(t)
+	c := &Colors{}
+	err := c.ApplyLSColors("rs=0:di=01;34:ln=01;36:ex=01;32:cd=33:*.TXT=35:*~=90:*.tar.gz=31:*README=33")
+	is.NotErr(err)
+	is.Equal(c.Dir.Name, &Style{Fg: 4, Bold: true})
+	is.Equal(c.Link.Name, &Style{Fg: 6, Bold: true})
+	is.Equal(c.Exec, &Style{Fg: 2, Bold: true})
+	is.Equal(c.CharDevice, &Style{Fg: 3})
+	is.Nil(c.Device)
+	is.Equal(c.File["txt"].Light, &Style{Fg: 5})
+	is.Equal(c.GetSuffixStyle("a.b~"), &Style{Fg: 8})
+	is.Equal(c.GetSuffixStyle("X.Tar.Gz"), &Style{Fg: 1})
+	is.Equal(c.GetSuffixStyle("my_readme"), &Style{Fg: 3})
+	is.Nil(c.GetSuffixStyle("a.gz"))
+
+	err = c.ApplyLSColors("di")
+	is.ErrMsg(err, `invalid entry "di", must be KEY=VALUE`)
+
+	// invalid entries are skipped
+	c = &Colors{}
+	err = c.ApplyLSColors("ex=01;x:di=01;34:ln")
+	is.ErrMsg(err, "entry \"ex=01;x\": invalid SGR code \"x\" in \"01;x\"\ninvalid entry \"ln\", must be KEY=VALUE")
+	is.Nil(c.Exec)
+	is.Equal(c.Dir.Name, &Style{Fg: 4, Bold: true})
+}
+
+func TestApplyLSColorsLinkTarget(t *testing.T) {
+	is := is.New(t)
+	// output of dircolors -b, with "LINK target"
+	data, err := os.ReadFile("testdata/ls_colors_link_target.txt")
+	is.NotErr(err)
+	c := &Colors{}
+	err = c.ApplyLSColors(strings.TrimSpace(string(data)))
+	is.NotErr(err)
+	is.True(c.Link.Target)
+	is.Nil(c.Link.Name)
+	is.Equal(c.Dir.Name, &Style{Fg: 4, Bold: true})
+	is.Equal(c.Link.Orphan, &Style{Fg: 1, Bg: 16, Bold: true})
+
+	err = c.ApplyLSColors("ln=01;36")
+	is.NotErr(err)
+	is.False(c.Link.Target)
+	is.Equal(c.Link.Name, &Style{Fg: 6, Bold: true})
+}
+
+func TestApplyBSDLSColors(t *testing.T) {
+	is := is.New(t)
+	c := &Colors{}
+	err := c.ApplyBSDLSColors("Exfxcxdxbxegedabagacad")
+	is.NotErr(err)
+	is.Equal(c.Dir.Name, &Style{Fg: 4, Bold: true})
+	is.Equal(c.Link.Name, &Style{Fg: 5})
+	is.Equal(c.Device, &Style{Fg: 4, Bg: 6})
+	is.Equal(c.Setuid, &Style{Fg: 16, Bg: 1})
+	is.Equal(c.Dir.OtherWritable, &Style{Fg: 16, Bg: 3})
+
+	err = c.ApplyBSDLSColors("exf")
+	is.ErrMsg(err, `invalid LSCOLORS "exf"`)
+	err = c.ApplyBSDLSColors("ez")
+	is.ErrMsg(err, `invalid color letter "z"`)
+}
@@ -0,0 +1,53 @@
+package lscolors
+
+import "reflect"
+
+var styleType = reflect.TypeOf(&Style{})
+
+// Merge sets colors that are set (non-nil) in other, recursively
+// a Style is replaced as a whole, maps are merged by key, and LightDark
+// pairs are merged by field
+func (c *Colors) Merge(other *Colors) {
+	mergeValue(reflect.ValueOf(c).Elem(), reflect.ValueOf(other).Elem())
+}
+
+func mergeValue(dst reflect.Value, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if src.Type() == styleType || dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		mergeValue(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			mergeValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(src.Type()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			value := iter.Value()
+			old := dst.MapIndex(iter.Key())
+			if old.IsValid() && value.Kind() == reflect.Struct {
+				merged := reflect.New(value.Type()).Elem()
+				merged.Set(old)
+				mergeValue(merged, value)
+				value = merged
+			}
+			dst.SetMapIndex(iter.Key(), value)
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
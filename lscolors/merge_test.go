@@ -0,0 +1,55 @@
+package lscolors
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestColorsMerge(t *testing.T) {
+	is := is.New(t)
+	tabular := &TabularColors{
+		TableHeader: Fg(4),
+		Summary:     Fg(5),
+	}
+	c := &Colors{
+		File: LightDarkMap{
+			"go":    {Light: Fg(1), Dark: Fg(2)},
+			DEFAULT: {Light: Fg(3), Dark: Fg(4)},
+		},
+		Dir:     DirColors{Name: Fg(5).SetBold(), Ext: Fg(6)},
+		Tabular: tabular,
+	}
+	c.Merge(&Colors{
+		File: LightDarkMap{
+			DEFAULT: {Light: Fg(7), Dark: Fg(8)},
+		},
+		Dir:     DirColors{Name: Fg(9)},
+		Tabular: &TabularColors{Summary: Fg(10)},
+		Html:    &HtmlColors{Default: Fg(11)},
+	})
+	is.Equal(c.File["go"], LightDark{Light: Fg(1), Dark: Fg(2)})
+	is.Equal(c.File[DEFAULT], LightDark{Light: Fg(7), Dark: Fg(8)})
+	is.Equal(c.Dir.Name, Fg(9))
+	is.Equal(c.Dir.Ext, Fg(6))
+	// merged in place
+	is.True(c.Tabular == tabular)
+	is.Equal(c.Tabular.TableHeader, Fg(4))
+	is.Equal(c.Tabular.Summary, Fg(10))
+	is.Equal(c.Html.Default, Fg(11))
+}
+
+func TestLightDarkGet(t *testing.T) {
+	is := is.New(t)
+	defer func() {
+		LightBackground = false
+	}()
+	ld := LightDark{Light: Fg(1), Dark: Fg(2)}
+	main, accent := ld.Get()
+	is.Equal(main, Fg(1))
+	is.Equal(accent, Fg(2))
+	LightBackground = true
+	main, accent = ld.Get()
+	is.Equal(main, Fg(2))
+	is.Equal(accent, Fg(1))
+}
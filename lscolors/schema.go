@@ -0,0 +1,125 @@
+package lscolors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// jsonFieldNames returns json names of fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]reflect.Type {
+	result := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		result[name] = field.Type
+	}
+	return result
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validateValue checks that value (decoded JSON) has no unknown keys,
+// according to given type
+func validateValue(value any, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			if value == nil {
+				return nil
+			}
+			return fmt.Errorf("%s: must be an object", path)
+		}
+		fields := jsonFieldNames(t)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldType, ok := fields[key]
+			if !ok {
+				return fmt.Errorf("unknown key %#v", joinPath(path, key))
+			}
+			if t == styleType.Elem() {
+				// style values are checked by Style.UnmarshalJSON
+				continue
+			}
+			err := validateValue(obj[key], fieldType, joinPath(path, key))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			if value == nil {
+				return nil
+			}
+			return fmt.Errorf("%s: must be an object", path)
+		}
+		for key, item := range obj {
+			err := validateValue(item, t.Elem(), joinPath(path, key))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		list, ok := value.([]any)
+		if !ok {
+			if value == nil {
+				return nil
+			}
+			return fmt.Errorf("%s: must be an array", path)
+		}
+		for i, item := range list {
+			err := validateValue(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseColors parses colors in JSON format (like --colors-json), and
+// returns an error for unknown keys
+// colors that are not given are nil, so result can be merged (with Merge)
+// over another Colors
+func ParseColors(data []byte) (*Colors, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	err = validateValue(value, reflect.TypeOf(Colors{}), "")
+	if err != nil {
+		return nil, err
+	}
+	c := &Colors{}
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,78 @@
+package lscolors
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseColors(t *testing.T) {
+	is := is.New(t)
+	c, err := ParseColors([]byte(`{
+		"dir": {"name": {"fg": 33, "bold": true}},
+		"file": {"go": {"light": {"fg": "#00add8"}}},
+		"tabular": {"summary": {"fg": 8}}
+	}`))
+	is.NotErr(err)
+	is.Equal(c.Dir.Name, Fg(33).SetBold())
+	is.Nil(c.Dir.Ext)
+	is.Equal(c.File["go"].Light.FgHex(), "#00add8")
+	is.Nil(c.File["go"].Dark)
+	is.Equal(c.Tabular.Summary, Fg(8))
+	is.Nil(c.Html)
+
+	_, err = ParseColors([]byte(`{"dir": {"nme": {"fg": 33}}}`))
+	is.ErrMsg(err, `unknown key "dir.nme"`)
+	_, err = ParseColors([]byte(`{"file": {"go": {"light": {"fgg": 1}}}}`))
+	is.ErrMsg(err, `unknown key "file.go.light.fgg"`)
+	_, err = ParseColors([]byte(`{"suffix": [{"suffix": "~", "color": {}}]}`))
+	is.ErrMsg(err, `unknown key "suffix[0].color"`)
+	_, err = ParseColors([]byte(`{"dir": 1}`))
+	is.ErrMsg(err, `dir: must be an object`)
+}
+
+func TestParseColorsMergeLightDark(t *testing.T) {
+	is := is.New(t)
+	c := &Colors{
+		File: LightDarkMap{"go": {Light: Fg(1), Dark: Fg(2)}},
+	}
+	other, err := ParseColors([]byte(`{"file": {"go": {"dark": {"fg": 3}}}}`))
+	is.NotErr(err)
+	c.Merge(other)
+	is.Equal(c.File["go"], LightDark{Light: Fg(1), Dark: Fg(3)})
+}
+
+func TestWalkStyles(t *testing.T) {
+	is := is.New(t)
+	c := &Colors{
+		File: LightDarkMap{"go": {Light: Fg(1), Dark: Fg(2)}},
+		Dir:  DirColors{Name: Fg(3)},
+		Size: StyleMap{"K": Fg(5), "B": Fg(4)},
+		Tabular: &TabularColors{
+			Summary: Fg(6),
+		},
+		Suffix: []*SuffixStyle{{Suffix: "~", Style: Fg(7)}},
+	}
+	paths := []string{}
+	c.WalkStyles(func(path string, style *Style) {
+		paths = append(paths, path)
+	})
+	is.Equal(paths, []string{
+		"file.go.light",
+		"file.go.dark",
+		"dir.name",
+		"size.B",
+		"size.K",
+		"tabular.summary",
+		"suffix[0].style",
+	})
+
+	c.MapStyles(func(style *Style) *Style {
+		return &Style{Fg: style.Fg + 10}
+	})
+	is.Equal(c.File["go"], LightDark{Light: Fg(11), Dark: Fg(12)})
+	is.Equal(c.Dir.Name, Fg(13))
+	is.Equal(c.Size["B"], Fg(14))
+	is.Equal(c.Tabular.Summary, Fg(16))
+	is.Equal(c.Suffix[0].Style, Fg(17))
+}
@@ -0,0 +1,115 @@
+package lscolors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// reset undoes ANSI color codes
+const reset = "\x1b[0m"
+
+// color code 0 means no color in Style, so we use 16 for black
+var colorNames = map[string]uint8{
+	"black":          16,
+	"red":            1,
+	"green":          2,
+	"yellow":         3,
+	"blue":           4,
+	"magenta":        5,
+	"cyan":           6,
+	"white":          7,
+	"gray":           8,
+	"grey":           8,
+	"bright-red":     9,
+	"bright-green":   10,
+	"bright-yellow":  11,
+	"bright-blue":    12,
+	"bright-magenta": 13,
+	"bright-cyan":    14,
+	"bright-white":   15,
+}
+
+// parseColorWord parses a color name, 256-color code or "#rrggbb"
+func parseColorWord(word string) (uint8, *RGB, error) {
+	if code, ok := colorNames[word]; ok {
+		return code, nil, nil
+	}
+	if strings.HasPrefix(word, "#") {
+		rgb, err := ParseHex(word)
+		if err != nil {
+			return 0, nil, err
+		}
+		return Nearest256(*rgb), rgb, nil
+	}
+	code, err := strconv.ParseUint(word, 10, 8)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid color %#v", word)
+	}
+	if code == 0 {
+		code = 16
+	}
+	return uint8(code), nil, nil
+}
+
+// ParseStyleSpec parses a human-readable style like "bold red on #303030":
+// attributes (bold, dim, italic, underline, reverse, strike), and foreground
+// and background (after "on") colors, given as name (like "red" or
+// "bright-blue"), 256-color code or "#rrggbb"
+func ParseStyleSpec(spec string) (*Style, error) {
+	style := &Style{}
+	words := strings.Fields(strings.ToLower(spec))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty style")
+	}
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		switch word {
+		case "bold":
+			style.Bold = true
+			continue
+		case "dim":
+			style.Dim = true
+			continue
+		case "italic":
+			style.Italic = true
+			continue
+		case "underline":
+			style.Underline = true
+			continue
+		case "reverse":
+			style.Reverse = true
+			continue
+		case "strike":
+			style.Strike = true
+			continue
+		case "on":
+			if i+1 == len(words) {
+				return nil, fmt.Errorf("missing color after \"on\" in %#v", spec)
+			}
+			i++
+			code, rgb, err := parseColorWord(words[i])
+			if err != nil {
+				return nil, err
+			}
+			style.Bg, style.BgRGB = code, rgb
+			continue
+		}
+		code, rgb, err := parseColorWord(word)
+		if err != nil {
+			return nil, err
+		}
+		style.Fg, style.FgRGB = code, rgb
+	}
+	return style, nil
+}
+
+// Highlight applies style to whole str, which may contain other styles
+// (ended with reset), like a table row
+func (s *Style) Highlight(str string) string {
+	st := s.S()
+	if st == "" {
+		return str
+	}
+	return st + strings.ReplaceAll(str, reset, reset+st) + reset
+}
@@ -0,0 +1,44 @@
+package lscolors
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseStyleSpec(t *testing.T) {
+	is := is.New(t)
+	test := func(spec string, expected *Style) {
+		style, err := ParseStyleSpec(spec)
+		is.AddMsg(spec).NotErr(err)
+		is.AddMsg(spec).Equal(style, expected)
+	}
+	test("red", Fg(1))
+	test("Bold Bright-Blue", Fg(12).SetBold())
+	test("black on white", &Style{Fg: 16, Bg: 7})
+	test("208 on 0 italic strike", &Style{Fg: 208, Bg: 16, Italic: true, Strike: true})
+	test("#ff8700", &Style{Fg: 208, FgRGB: &RGB{R: 0xff, G: 0x87}})
+	test("dim underline reverse", &Style{Dim: true, Underline: true, Reverse: true})
+
+	_, err := ParseStyleSpec(" ")
+	is.ErrMsg(err, "empty style")
+	_, err = ParseStyleSpec("red on")
+	is.ErrMsg(err, `missing color after "on" in "red on"`)
+	_, err = ParseStyleSpec("256")
+	is.ErrMsg(err, `invalid color "256"`)
+	_, err = ParseStyleSpec("#ff87")
+	is.ErrMsg(err, `invalid color "#ff87", must be #rrggbb`)
+}
+
+func TestStyleHighlight(t *testing.T) {
+	is := is.New(t)
+	style := Bg(236)
+	is.Equal(
+		style.SDepth(Depth256), "\x1b[48;5;236m",
+	)
+	is.Equal(
+		style.Highlight("a \x1b[31mb\x1b[0m c"),
+		"\x1b[48;5;236ma \x1b[31mb\x1b[0m\x1b[48;5;236m c\x1b[0m",
+	)
+	is.Equal((&Style{}).Highlight("a"), "a")
+}
@@ -0,0 +1,60 @@
+package lscolors
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WalkStyles calls f for every (non-nil) style in colors, with its path
+// (json keys joined by ".", like "dir.name"), maps are walked in order of keys
+func (c *Colors) WalkStyles(f func(path string, style *Style)) {
+	walkStyles(reflect.ValueOf(c).Elem(), "", func(path string, v reflect.Value) {
+		f(path, v.Interface().(*Style))
+	})
+}
+
+// MapStyles replaces every (non-nil) style in colors with the result of f
+func (c *Colors) MapStyles(f func(style *Style) *Style) {
+	walkStyles(reflect.ValueOf(c).Elem(), "", func(_ string, v reflect.Value) {
+		v.Set(reflect.ValueOf(f(v.Interface().(*Style))))
+	})
+}
+
+// walkStyles calls f with settable values of type *Style
+func walkStyles(v reflect.Value, path string, f func(path string, v reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == styleType {
+			f(path, v)
+			return
+		}
+		walkStyles(v.Elem(), path, f)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			walkStyles(v.Field(i), joinPath(path, name), f)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, key := range keys {
+			// map values are not settable, so we walk a copy and set it back
+			item := reflect.New(v.Type().Elem()).Elem()
+			item.Set(v.MapIndex(key))
+			walkStyles(item, joinPath(path, key.String()), f)
+			v.SetMapIndex(key, item)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkStyles(v.Index(i), fmt.Sprintf("%s[%d]", path, i), f)
+		}
+	}
+}
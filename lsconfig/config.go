@@ -0,0 +1,158 @@
+package lsconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SourceConfigFile  = "config file"
+	SourceCommandLine = "command line"
+	SourceEnvColors   = "LSGO_COLORS"
+)
+
+// Settings is what can be set in config file, either at top level
+// (defaults) or in a named profile
+//
+// Flags maps long flag names (without "--") to values: true for flags
+// without argument, string or number for flags with argument
+//
+// Colors has the same format as LSGO_COLORS and --colors-json
+//
+// Icons maps (lowercase) extensions or file names to icons, and
+// FolderIcons maps folder names to icons
+//
+// Macros maps names to expressions, which can be used by name in
+// --expr, --where, --sort-expr and --group-expr
+//
+// ColorRules are rules like --color-rule, applied before rules of
+// command line
+type Settings struct {
+	Flags       map[string]any    `json:"flags,omitempty"`
+	Colors      json.RawMessage   `json:"colors,omitempty"`
+	Icons       map[string]string `json:"icons,omitempty"`
+	FolderIcons map[string]string `json:"folder_icons,omitempty"`
+	Macros      map[string]string `json:"macros,omitempty"`
+	ColorRules  []string          `json:"color_rules,omitempty"`
+}
+
+type Config struct {
+	Settings
+	Profiles map[string]*Settings `json:"profiles,omitempty"`
+}
+
+// DefaultPath returns path of config file: $LSGO_CONFIG if set, otherwise
+// ls-go/config.json in user config directory ($XDG_CONFIG_HOME or ~/.config
+// on Linux)
+func DefaultPath() string {
+	path := os.Getenv("LSGO_CONFIG")
+	if path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ls-go", "config.json")
+}
+
+// ThemePath returns path of theme file for given theme name or path
+// a name (without ".json" suffix and path separator) is looked up in
+// "themes" directory next to config file
+func ThemePath(name string) string {
+	if strings.HasSuffix(name, ".json") || strings.ContainsAny(name, `/\`) {
+		return name
+	}
+	return filepath.Join(filepath.Dir(DefaultPath()), "themes", name+".json")
+}
+
+// Load reads and parses config file, returns nil Config (and no error)
+// if file does not exist
+func Load(path string) (*Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	conf := &Config{}
+	err = json.Unmarshal(data, conf)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return conf, nil
+}
+
+// Profile returns settings of given profile name
+func (conf *Config) Profile(name string) (*Settings, error) {
+	profile := conf.Profiles[name]
+	if profile == nil {
+		return nil, fmt.Errorf("profile %#v not found in config file", name)
+	}
+	return profile, nil
+}
+
+// ProfileSource is the source name shown by --print-config
+func ProfileSource(name string) string {
+	return "profile " + strconv.Quote(name)
+}
+
+// FlagArgs converts Flags into command line arguments, sorted by flag name
+func (s *Settings) FlagArgs() ([]string, error) {
+	names := make([]string, 0, len(s.Flags))
+	for name := range s.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		list, isList := s.Flags[name].([]any)
+		if isList && repeatableFlags["--"+name] {
+			// one argument for each value, like --expr=a --expr=b
+			for _, value := range list {
+				str, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid value %#v in flag %#v, must be string", value, name)
+				}
+				result = append(result, "--"+name+"="+str)
+			}
+			continue
+		}
+		arg, err := flagArg(name, s.Flags[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, arg)
+	}
+	return result, nil
+}
+
+func flagArg(name string, value any) (string, error) {
+	switch vt := value.(type) {
+	case bool:
+		if !vt {
+			// unsets the flag if given by config file (for a profile)
+			return "--" + name + "=false", nil
+		}
+		return "--" + name, nil
+	case string:
+		return "--" + name + "=" + vt, nil
+	case float64:
+		return "--" + name + "=" + strconv.FormatFloat(vt, 'f', -1, 64), nil
+	}
+	if repeatableFlags["--"+name] {
+		return "", fmt.Errorf("invalid value %#v for flag %#v, must be string or array of strings", value, name)
+	}
+	return "", fmt.Errorf("invalid value %#v for flag %#v, must be boolean, string or number", value, name)
+}
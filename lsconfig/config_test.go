@@ -0,0 +1,151 @@
+package lsconfig
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func testFlagLookup(arg string) *FlagInfo {
+	switch arg {
+	case "--sort", "--so":
+		return &FlagInfo{Name: "--sort", Main: "--sort", HasValue: true}
+	case "--long", "-l":
+		return &FlagInfo{Name: arg, Main: "--long"}
+	case "--icons", "--profile":
+		return &FlagInfo{Name: arg, Main: arg}
+	case "-a":
+		return &FlagInfo{Name: arg, Main: "--all"}
+	case "-t":
+		return &FlagInfo{Name: arg, Main: arg, Target: "--sort"}
+	}
+	return nil
+}
+
+func TestFlagArgs(t *testing.T) {
+	is := is.New(t)
+	s := &Settings{Flags: map[string]any{
+		"sort":  "ext",
+		"long":  true,
+		"icons": false,
+		"width": float64(80),
+	}}
+	flagArgs, err := s.FlagArgs()
+	is.NotErr(err)
+	is.Equal(flagArgs, []string{"--icons=false", "--long", "--sort=ext", "--width=80"})
+
+	s = &Settings{Flags: map[string]any{"sort": []any{"a"}}}
+	_, err = s.FlagArgs()
+	is.ErrMsg(err, `invalid value []interface {}{"a"} for flag "sort", must be boolean, string or number`)
+
+	s = &Settings{Flags: map[string]any{
+		"expr":       []any{"owner", "kib=size/1024"},
+		"color-rule": "size > 0 => green",
+	}}
+	flagArgs, err = s.FlagArgs()
+	is.NotErr(err)
+	is.Equal(flagArgs, []string{"--color-rule=size > 0 => green", "--expr=owner", "--expr=kib=size/1024"})
+
+	s = &Settings{Flags: map[string]any{"expr": []any{"owner", float64(1)}}}
+	_, err = s.FlagArgs()
+	is.ErrMsg(err, `invalid value 1 in flag "expr", must be string`)
+
+	s = &Settings{Flags: map[string]any{"expr": map[string]any{}}}
+	_, err = s.FlagArgs()
+	is.ErrMsg(err, `invalid value map[string]interface {}{} for flag "expr", must be string or array of strings`)
+}
+
+func TestEffective(t *testing.T) {
+	is := is.New(t)
+	e := NewEffective("config.json", "audit")
+	flagArgs, err := e.AddSettings(&Settings{
+		Flags:  map[string]any{"sort": "ext", "long": true},
+		Macros: map[string]string{"big": "size > 1e6"},
+	}, SourceConfigFile, testFlagLookup)
+	is.NotErr(err)
+	is.Equal(flagArgs, []string{"--long", "--sort=ext"})
+	_, err = e.AddSettings(&Settings{
+		Flags:  map[string]any{"icons": true},
+		Macros: map[string]string{"big": "size > 1e9"},
+	}, ProfileSource("audit"), testFlagLookup)
+	is.NotErr(err)
+	e.AddArgs([]string{"--so", "size", "-a", "--", "--long"}, SourceCommandLine, testFlagLookup)
+
+	type flagSource struct {
+		arg    string
+		source string
+	}
+	actual := []flagSource{}
+	for _, flag := range e.Flags {
+		source := flag.Source
+		if flag.OverriddenBy != "" {
+			source += " > " + flag.OverriddenBy
+		}
+		actual = append(actual, flagSource{flag.Arg, source})
+	}
+	is.Equal(actual, []flagSource{
+		{"--long", "config file"},
+		{"--sort=ext", "config file > command line"},
+		{"--icons", `profile "audit"`},
+		{"--so size", "command line"},
+		{"-a", "command line"},
+	})
+	is.Equal(e.Macros["big"], &Value{Value: "size > 1e9", Source: `profile "audit"`})
+
+	_, err = e.AddSettings(&Settings{
+		Flags: map[string]any{"foo": true},
+	}, SourceConfigFile, testFlagLookup)
+	is.ErrMsg(err, `config file: unknown flag "foo"`)
+	_, err = e.AddSettings(&Settings{
+		Flags: map[string]any{"profile": "x"},
+	}, SourceConfigFile, testFlagLookup)
+	is.ErrMsg(err, `config file: can not set profile in flags`)
+}
+
+func TestEffectiveShortFlags(t *testing.T) {
+	is := is.New(t)
+	e := NewEffective("config.json", "")
+	_, err := e.AddSettings(&Settings{
+		Flags: map[string]any{"sort": "ext", "long": true},
+	}, SourceConfigFile, testFlagLookup)
+	is.NotErr(err)
+	e.AddArgs([]string{"-la", "-t", "--sort", "size", "x"}, SourceCommandLine, testFlagLookup)
+	type flagSource struct {
+		arg        string
+		name       string
+		overridden string
+	}
+	actual := []flagSource{}
+	for _, flag := range e.Flags {
+		actual = append(actual, flagSource{flag.Arg, flag.Name, flag.OverriddenBy})
+	}
+	is.Equal(actual, []flagSource{
+		{"--long", "--long", SourceCommandLine},
+		{"--sort=ext", "--sort", SourceCommandLine},
+		{"-l", "--long", ""},
+		{"-a", "--all", ""},
+		// --sort does not override -t, because -t is applied last
+		{"-t", "-t", ""},
+		{"--sort size", "--sort", ""},
+	})
+}
+
+func TestEffectiveColorRules(t *testing.T) {
+	is := is.New(t)
+	lookup := func(arg string) *FlagInfo {
+		return &FlagInfo{Name: arg, Main: arg, HasValue: true}
+	}
+	e := NewEffective("config.json", "")
+	_, err := e.AddSettings(&Settings{
+		ColorRules: []string{"size > 1e9 => red", "dir == '.' => bold"},
+	}, SourceConfigFile, lookup)
+	is.NotErr(err)
+	e.AddArgs([]string{"--color-rule=size > 0 => green", "--color-rule=size == 0 => gray"}, SourceCommandLine, lookup)
+	is.Equal(e.ColorRules, []*Value{
+		{Value: "size > 1e9 => red", Source: SourceConfigFile},
+		{Value: "dir == '.' => bold", Source: SourceConfigFile},
+	})
+	// repeatable flag is not overridden
+	is.Equal(len(e.Flags), 2)
+	is.Equal(e.Flags[0].OverriddenBy, "")
+}
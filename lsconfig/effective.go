@@ -0,0 +1,238 @@
+package lsconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Flag is a command line argument with the source it came from
+// OverriddenBy is set if the same flag is given again by a later source
+type Flag struct {
+	Arg          string
+	Name         string
+	Source       string
+	OverriddenBy string
+}
+
+type Value struct {
+	Value  string
+	Source string
+}
+
+type Colors struct {
+	JSON   json.RawMessage
+	Source string
+}
+
+// Effective is the result of merging config file, profile and command line
+// in that order, each one overriding the previous ones
+type Effective struct {
+	Path    string
+	Profile string
+
+	Flags       []*Flag
+	Colors      []*Colors
+	Icons       map[string]*Value
+	FolderIcons map[string]*Value
+	Macros      map[string]*Value
+	ColorRules  []*Value
+}
+
+func NewEffective(path string, profile string) *Effective {
+	return &Effective{
+		Path:        path,
+		Profile:     profile,
+		Icons:       map[string]*Value{},
+		FolderIcons: map[string]*Value{},
+		Macros:      map[string]*Value{},
+	}
+}
+
+func mergeValues(target map[string]*Value, values map[string]string, source string) {
+	for key, value := range values {
+		target[key] = &Value{Value: value, Source: source}
+	}
+}
+
+// FlagInfo is what we know about a command line flag
+type FlagInfo struct {
+	Name     string // full name of flag, like "--sort" for "--so"
+	Main     string // main name of flag, like "--long" for "-l"
+	Target   string // the flag that is set by this shortcut flag, like "--sort" for "-t"
+	HasValue bool   // takes a value, like "--sort=size" or "--sort size"
+}
+
+// FlagLookup returns info of given flag (without value), or nil
+// if flag is not known
+type FlagLookup func(arg string) *FlagInfo
+
+// AddSettings merges given settings and returns their flags as
+// command line arguments
+func (e *Effective) AddSettings(s *Settings, source string, lookup FlagLookup) ([]string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	for name := range s.Flags {
+		info := lookup("--" + name)
+		if info == nil || info.Name != "--"+name {
+			return nil, fmt.Errorf("%s: unknown flag %#v", source, name)
+		}
+		if name == "profile" {
+			return nil, fmt.Errorf("%s: can not set profile in flags", source)
+		}
+	}
+	flagArgs, err := s.FlagArgs()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if len(s.Colors) > 0 {
+		e.Colors = append(e.Colors, &Colors{JSON: s.Colors, Source: source})
+	}
+	mergeValues(e.Icons, s.Icons, source)
+	mergeValues(e.FolderIcons, s.FolderIcons, source)
+	mergeValues(e.Macros, s.Macros, source)
+	for _, rule := range s.ColorRules {
+		e.ColorRules = append(e.ColorRules, &Value{Value: rule, Source: source})
+	}
+	e.AddArgs(flagArgs, source, lookup)
+	return flagArgs, nil
+}
+
+// repeatableFlags can be given more than once, and all values are used
+var repeatableFlags = map[string]bool{
+	"--color-rule": true,
+	"--expr":       true,
+}
+
+// isFlagValue returns true if arg can be the value of previous flag,
+// like goopt does: it must not look like a flag
+func isFlagValue(arg string) bool {
+	return arg == "-" || (arg != "" && arg[0] != '-')
+}
+
+// addFlag records a flag, and marks previous flags that it overrides
+// shortcut flags (like -t) override their target (like --sort), but
+// not the other way around, because shortcut flags are applied last
+func (e *Effective) addFlag(arg string, info *FlagInfo, source string) {
+	if !repeatableFlags[info.Main] {
+		for _, flag := range e.Flags {
+			if flag.OverriddenBy != "" {
+				continue
+			}
+			if flag.Name == info.Main || (info.Target != "" && flag.Name == info.Target) {
+				flag.OverriddenBy = source
+			}
+		}
+	}
+	e.Flags = append(e.Flags, &Flag{
+		Arg:    arg,
+		Name:   info.Main,
+		Source: source,
+	})
+}
+
+// AddArgs records command line arguments (flags) from given source
+// combined short flags (like -la) are recorded separately, and arguments
+// after "--" are ignored
+func (e *Effective) AddArgs(args []string, source string, lookup FlagLookup) {
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		if arg[1] == '-' {
+			nameArg, _, hasValue := strings.Cut(arg, "=")
+			info := lookup(nameArg)
+			if info == nil {
+				info = &FlagInfo{Name: nameArg, Main: nameArg}
+			}
+			if info.HasValue && !hasValue && index+1 < len(args) && isFlagValue(args[index+1]) {
+				index++
+				arg += " " + args[index]
+			}
+			e.addFlag(arg, info, source)
+			continue
+		}
+		for _, char := range arg[1:] {
+			shortArg := "-" + string(char)
+			info := lookup(shortArg)
+			if info == nil {
+				info = &FlagInfo{Name: shortArg, Main: shortArg}
+			}
+			if info.HasValue && index+1 < len(args) && isFlagValue(args[index+1]) {
+				index++
+				shortArg += " " + args[index]
+			}
+			e.addFlag(shortArg, info, source)
+		}
+	}
+}
+
+func sortedKeys(m map[string]*Value) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func printValues(w io.Writer, title string, values map[string]*Value) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\n"+title+":")
+	for _, key := range sortedKeys(values) {
+		value := values[key]
+		fmt.Fprintf(w, "  %s = %s\t# %s\n", key, value.Value, value.Source)
+	}
+}
+
+// Print writes the effective config for --print-config
+func (e *Effective) Print(w io.Writer) {
+	if e.Path == "" {
+		fmt.Fprintln(w, "config file: none")
+	} else {
+		fmt.Fprintln(w, "config file: "+e.Path)
+	}
+	if e.Profile != "" {
+		fmt.Fprintln(w, "profile: "+e.Profile)
+	}
+	if len(e.Flags) > 0 {
+		fmt.Fprintln(w, "\nflags:")
+		for _, flag := range e.Flags {
+			source := flag.Source
+			if flag.OverriddenBy != "" {
+				source += ", overridden by " + flag.OverriddenBy
+			}
+			fmt.Fprintf(w, "  %s\t# %s\n", flag.Arg, source)
+		}
+	}
+	printValues(w, "macros", e.Macros)
+	printValues(w, "icons", e.Icons)
+	printValues(w, "folder icons", e.FolderIcons)
+	if len(e.ColorRules) > 0 {
+		fmt.Fprintln(w, "\ncolor rules:")
+		for _, rule := range e.ColorRules {
+			fmt.Fprintf(w, "  %s\t# %s\n", rule.Value, rule.Source)
+		}
+	}
+	if len(e.Colors) > 0 {
+		fmt.Fprintln(w, "\ncolors:")
+		for _, colors := range e.Colors {
+			buf := bytes.NewBuffer(nil)
+			err := json.Compact(buf, colors.JSON)
+			if err != nil {
+				buf = bytes.NewBuffer(colors.JSON)
+			}
+			fmt.Fprintf(w, "  %s\t# %s\n", buf.String(), colors.Source)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package lslocale
+
+import (
+	"os"
+	"strings"
+)
+
+// Locale has the quotation marks and time formats of a locale
+// time formats are in strftime format, with names localized by Locale.Strftime
+// RecentFormat is used for times in the last six months, and OldFormat for
+// older times and times in the future (like GNU ls)
+type Locale struct {
+	Name string
+
+	QuoteOpen  string
+	QuoteClose string
+
+	Months     [12]string
+	MonthsAbbr [12]string
+	Days       [7]string
+	DaysAbbr   [7]string
+	AM         string
+	PM         string
+
+	RecentFormat string
+	OldFormat    string
+}
+
+// Env returns locale name of given category (like "LC_TIME"), from
+// LC_ALL, the category or LANG environment variables (whichever is set first)
+func Env(category string) string {
+	for _, key := range []string{"LC_ALL", category, "LANG"} {
+		value := os.Getenv(key)
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Parse splits a locale name like "de_DE.UTF-8@euro" into language,
+// territory and codeset
+func Parse(name string) (lang string, territory string, codeset string) {
+	name, _, _ = strings.Cut(name, "@")
+	name, codeset, _ = strings.Cut(name, ".")
+	lang, territory, _ = strings.Cut(name, "_")
+	return lang, territory, codeset
+}
+
+func isUTF8(codeset string) bool {
+	codeset = strings.ToLower(codeset)
+	return codeset == "utf-8" || codeset == "utf8"
+}
+
+// IsPOSIX returns true if name is empty or "C" or "POSIX" locale
+// (with any codeset)
+func IsPOSIX(name string) bool {
+	lang, _, _ := Parse(name)
+	return lang == "" || lang == "C" || lang == "POSIX"
+}
+
+// ForName returns the locale with given name (like "de_DE.UTF-8")
+// unknown languages fall back to "C" locale, with Unicode quotation marks
+// if codeset is UTF-8
+func ForName(name string) *Locale {
+	lang, territory, codeset := Parse(name)
+	base := locales[lang]
+	if base == nil || IsPOSIX(name) {
+		base = cLocale
+	}
+	locale := *base
+	locale.Name = name
+	if base == cLocale {
+		if isUTF8(codeset) {
+			locale.QuoteOpen, locale.QuoteClose = "‘", "’"
+		}
+		return &locale
+	}
+	variant := variants[lang+"_"+territory]
+	if variant != nil {
+		variant(&locale)
+	}
+	return &locale
+}
+
+// ForTime returns the locale used for time formatting (LC_TIME)
+func ForTime() *Locale {
+	return ForName(Env("LC_TIME"))
+}
+
+// ForMessages returns the locale used for quotation marks (LC_MESSAGES)
+func ForMessages() *Locale {
+	return ForName(Env("LC_MESSAGES"))
+}
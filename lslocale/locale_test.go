@@ -0,0 +1,62 @@
+package lslocale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParse(t *testing.T) {
+	is := is.New(t)
+	lang, territory, codeset := Parse("de_DE.UTF-8@euro")
+	is.Equal(lang, "de")
+	is.Equal(territory, "DE")
+	is.Equal(codeset, "UTF-8")
+	lang, territory, codeset = Parse("C")
+	is.Equal(lang, "C")
+	is.Equal(territory, "")
+	is.Equal(codeset, "")
+}
+
+func TestForName(t *testing.T) {
+	is := is.New(t)
+	test := func(name string, quoteOpen string, quoteClose string, recent string) {
+		locale := ForName(name)
+		is := is.AddMsg("name=%#v", name)
+		is.Equal(locale.QuoteOpen, quoteOpen)
+		is.Equal(locale.QuoteClose, quoteClose)
+		is.Equal(locale.RecentFormat, recent)
+	}
+	test("", "'", "'", "%b %e %H:%M")
+	test("C", "'", "'", "%b %e %H:%M")
+	test("C.UTF-8", "‘", "’", "%b %e %H:%M")
+	test("xx_YY", "'", "'", "%b %e %H:%M")
+	test("en_US.UTF-8", "‘", "’", "%b %e %H:%M")
+	test("en_GB.UTF-8", "‘", "’", "%e %b %H:%M")
+	test("de_DE.UTF-8", "„", "“", "%e. %b %H:%M")
+	test("pt_PT.UTF-8", "«", "»", "%e %b %H:%M")
+	test("pt_BR.UTF-8", "“", "”", "%e %b %H:%M")
+}
+
+func TestStrftime(t *testing.T) {
+	is := is.New(t)
+	tm := time.Date(2026, 10, 19, 15, 4, 5, 0, time.UTC)
+	locale := ForName("de_DE.UTF-8")
+	is.Equal(locale.Strftime(tm, "%A, %d. %B %Y"), "Montag, 19. Oktober 2026")
+	is.Equal(locale.Strftime(tm, "%a %b %p %%b %-d"), "Mo Okt PM %b 19")
+	is.Equal(ForName("ja_JP.UTF-8").Strftime(tm, "%p"), "午後")
+}
+
+func TestPaddedMonthAbbr(t *testing.T) {
+	is := is.New(t)
+	may := time.Date(2026, 5, 5, 9, 7, 0, 0, time.UTC)
+	oct := time.Date(2026, 10, 19, 9, 7, 0, 0, time.UTC)
+	fr := ForName("fr_FR.UTF-8")
+	is.Equal(fr.Strftime(may, "%b|"), "mai  |")
+	is.Equal(fr.Strftime(oct, "%b|"), "oct. |")
+	is.Equal(fr.Strftime(may, "%B|"), "mai|")
+	ja := ForName("ja_JP.UTF-8")
+	is.Equal(ja.Strftime(may, "%b|"), " 5月|")
+	is.Equal(ja.Strftime(oct, "%b|"), "10月|")
+}
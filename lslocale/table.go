@@ -0,0 +1,331 @@
+package lslocale
+
+// month and day names are from CLDR, and time formats are from
+// translations of GNU coreutils
+
+var englishNames = Locale{
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	MonthsAbbr: [12]string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	},
+	Days: [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	},
+	DaysAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	AM:       "AM",
+	PM:       "PM",
+}
+
+func withNames(names Locale, locale Locale) *Locale {
+	locale.Months = names.Months
+	locale.MonthsAbbr = names.MonthsAbbr
+	locale.Days = names.Days
+	locale.DaysAbbr = names.DaysAbbr
+	if locale.AM == "" {
+		locale.AM, locale.PM = names.AM, names.PM
+	}
+	if locale.AM == "" {
+		locale.AM, locale.PM = englishNames.AM, englishNames.PM
+	}
+	return &locale
+}
+
+// cLocale is the "C" (or "POSIX") locale
+var cLocale = withNames(englishNames, Locale{
+	QuoteOpen:    "'",
+	QuoteClose:   "'",
+	RecentFormat: "%b %e %H:%M",
+	OldFormat:    "%b %e  %Y",
+})
+
+// locales maps language codes to locales
+var locales = map[string]*Locale{
+	"en": withNames(englishNames, Locale{
+		QuoteOpen:    "‘",
+		QuoteClose:   "’",
+		RecentFormat: "%b %e %H:%M",
+		OldFormat:    "%b %e  %Y",
+	}),
+	"de": withNames(Locale{
+		Months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		MonthsAbbr: [12]string{
+			"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+			"Jul", "Aug", "Sep", "Okt", "Nov", "Dez",
+		},
+		Days: [7]string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag",
+		},
+		DaysAbbr: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	}, Locale{
+		QuoteOpen:    "„",
+		QuoteClose:   "“",
+		RecentFormat: "%e. %b %H:%M",
+		OldFormat:    "%e. %b %Y",
+	}),
+	"fr": withNames(Locale{
+		Months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		MonthsAbbr: [12]string{
+			"janv.", "févr.", "mars", "avril", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+		},
+		Days: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+		},
+		DaysAbbr: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	}, Locale{
+		QuoteOpen:    "« ",
+		QuoteClose:   " »",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b %Y",
+	}),
+	"es": withNames(Locale{
+		Months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		MonthsAbbr: [12]string{
+			"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sep", "oct", "nov", "dic",
+		},
+		Days: [7]string{
+			"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado",
+		},
+		DaysAbbr: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	}, Locale{
+		QuoteOpen:    "«",
+		QuoteClose:   "»",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"it": withNames(Locale{
+		Months: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+		MonthsAbbr: [12]string{
+			"gen", "feb", "mar", "apr", "mag", "giu",
+			"lug", "ago", "set", "ott", "nov", "dic",
+		},
+		Days: [7]string{
+			"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato",
+		},
+		DaysAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	}, Locale{
+		QuoteOpen:    "«",
+		QuoteClose:   "»",
+		RecentFormat: "%e %b %H.%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"pt": withNames(Locale{
+		Months: [12]string{
+			"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+			"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+		},
+		MonthsAbbr: [12]string{
+			"jan", "fev", "mar", "abr", "mai", "jun",
+			"jul", "ago", "set", "out", "nov", "dez",
+		},
+		Days: [7]string{
+			"domingo", "segunda-feira", "terça-feira", "quarta-feira",
+			"quinta-feira", "sexta-feira", "sábado",
+		},
+		DaysAbbr: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	}, Locale{
+		QuoteOpen:    "«",
+		QuoteClose:   "»",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"nl": withNames(Locale{
+		Months: [12]string{
+			"januari", "februari", "maart", "april", "mei", "juni",
+			"juli", "augustus", "september", "oktober", "november", "december",
+		},
+		MonthsAbbr: [12]string{
+			"jan", "feb", "mrt", "apr", "mei", "jun",
+			"jul", "aug", "sep", "okt", "nov", "dec",
+		},
+		Days: [7]string{
+			"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag",
+		},
+		DaysAbbr: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	}, Locale{
+		QuoteOpen:    "‘",
+		QuoteClose:   "’",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"sv": withNames(Locale{
+		Months: [12]string{
+			"januari", "februari", "mars", "april", "maj", "juni",
+			"juli", "augusti", "september", "oktober", "november", "december",
+		},
+		MonthsAbbr: [12]string{
+			"jan", "feb", "mar", "apr", "maj", "jun",
+			"jul", "aug", "sep", "okt", "nov", "dec",
+		},
+		Days: [7]string{
+			"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag",
+		},
+		DaysAbbr: [7]string{"sön", "mån", "tis", "ons", "tor", "fre", "lör"},
+	}, Locale{
+		QuoteOpen:    "”",
+		QuoteClose:   "”",
+		RecentFormat: "%e %b %H.%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"pl": withNames(Locale{
+		Months: [12]string{
+			"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec",
+			"lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień",
+		},
+		MonthsAbbr: [12]string{
+			"sty", "lut", "mar", "kwi", "maj", "cze",
+			"lip", "sie", "wrz", "paź", "lis", "gru",
+		},
+		Days: [7]string{
+			"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota",
+		},
+		DaysAbbr: [7]string{"nie", "pon", "wto", "śro", "czw", "pią", "sob"},
+	}, Locale{
+		QuoteOpen:    "„",
+		QuoteClose:   "”",
+		RecentFormat: "%b %d %H:%M",
+		OldFormat:    "%b %d  %Y",
+	}),
+	"ru": withNames(Locale{
+		Months: [12]string{
+			"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря",
+		},
+		MonthsAbbr: [12]string{
+			"янв", "фев", "мар", "апр", "мая", "июн",
+			"июл", "авг", "сен", "окт", "ноя", "дек",
+		},
+		Days: [7]string{
+			"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота",
+		},
+		DaysAbbr: [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+	}, Locale{
+		QuoteOpen:    "«",
+		QuoteClose:   "»",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+	"ja": withNames(Locale{
+		Months: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		MonthsAbbr: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		Days: [7]string{
+			"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日",
+		},
+		DaysAbbr: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	}, Locale{
+		QuoteOpen:    "「",
+		QuoteClose:   "」",
+		AM:           "午前",
+		PM:           "午後",
+		RecentFormat: "%b %e日 %H:%M",
+		OldFormat:    "%Y年 %b %e日",
+	}),
+	"zh": withNames(Locale{
+		Months: [12]string{
+			"一月", "二月", "三月", "四月", "五月", "六月",
+			"七月", "八月", "九月", "十月", "十一月", "十二月",
+		},
+		MonthsAbbr: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		Days: [7]string{
+			"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六",
+		},
+		DaysAbbr: [7]string{"日", "一", "二", "三", "四", "五", "六"},
+	}, Locale{
+		QuoteOpen:    "“",
+		QuoteClose:   "”",
+		AM:           "上午",
+		PM:           "下午",
+		RecentFormat: "%b %e %H:%M",
+		OldFormat:    "%Y年%m月%d日",
+	}),
+	"ko": withNames(Locale{
+		Months: [12]string{
+			"1월", "2월", "3월", "4월", "5월", "6월",
+			"7월", "8월", "9월", "10월", "11월", "12월",
+		},
+		MonthsAbbr: [12]string{
+			"1월", "2월", "3월", "4월", "5월", "6월",
+			"7월", "8월", "9월", "10월", "11월", "12월",
+		},
+		Days: [7]string{
+			"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일",
+		},
+		DaysAbbr: [7]string{"일", "월", "화", "수", "목", "금", "토"},
+	}, Locale{
+		QuoteOpen:    "‘",
+		QuoteClose:   "’",
+		AM:           "오전",
+		PM:           "오후",
+		RecentFormat: "%b %e일 %H:%M",
+		OldFormat:    "%Y년 %b %e일",
+	}),
+	"tr": withNames(Locale{
+		Months: [12]string{
+			"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran",
+			"Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık",
+		},
+		MonthsAbbr: [12]string{
+			"Oca", "Şub", "Mar", "Nis", "May", "Haz",
+			"Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara",
+		},
+		Days: [7]string{
+			"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi",
+		},
+		DaysAbbr: [7]string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+	}, Locale{
+		QuoteOpen:    "“",
+		QuoteClose:   "”",
+		RecentFormat: "%e %b %H:%M",
+		OldFormat:    "%e %b  %Y",
+	}),
+}
+
+// variants maps locales (language and territory) to changes over
+// the locale of language
+var variants = map[string]func(l *Locale){
+	"en_GB": dayFirst,
+	"en_AU": dayFirst,
+	"en_IE": dayFirst,
+	"en_NZ": dayFirst,
+	"en_IN": dayFirst,
+	"pt_BR": func(l *Locale) {
+		l.QuoteOpen, l.QuoteClose = "“", "”"
+	},
+	"zh_TW": func(l *Locale) {
+		l.QuoteOpen, l.QuoteClose = "「", "」"
+	},
+	"zh_HK": func(l *Locale) {
+		l.QuoteOpen, l.QuoteClose = "「", "」"
+	},
+}
+
+func dayFirst(l *Locale) {
+	l.RecentFormat = "%e %b %H:%M"
+	l.OldFormat = "%e %b  %Y"
+}
@@ -0,0 +1,71 @@
+package lslocale
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+// Strftime is like timefmt.Format, but with localized names for
+// %a, %A, %b, %h, %B and %p
+// like GNU ls, %b is padded to the same width for all months
+func (l *Locale) Strftime(tm time.Time, format string) string {
+	return timefmt.Format(tm, l.localizeFormat(tm, format))
+}
+
+func escapePercent(str string) string {
+	return strings.ReplaceAll(str, "%", "%%")
+}
+
+// localizeFormat replaces localized conversions with names of tm
+func (l *Locale) localizeFormat(tm time.Time, format string) string {
+	if !strings.Contains(format, "%") {
+		return format
+	}
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'a':
+			b.WriteString(escapePercent(l.DaysAbbr[tm.Weekday()]))
+		case 'A':
+			b.WriteString(escapePercent(l.Days[tm.Weekday()]))
+		case 'b', 'h':
+			b.WriteString(escapePercent(l.paddedMonthAbbr(tm.Month())))
+		case 'B':
+			b.WriteString(escapePercent(l.Months[tm.Month()-1]))
+		case 'p':
+			if tm.Hour() < 12 {
+				b.WriteString(escapePercent(l.AM))
+			} else {
+				b.WriteString(escapePercent(l.PM))
+			}
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+// paddedMonthAbbr returns abbreviated month name padded with spaces to the
+// width of longest one, aligned to right if it starts with a digit
+func (l *Locale) paddedMonthAbbr(month time.Month) string {
+	maxWidth := 0
+	for _, name := range l.MonthsAbbr {
+		maxWidth = max(maxWidth, utf8.RuneCountInString(name))
+	}
+	name := l.MonthsAbbr[month-1]
+	padding := strings.Repeat(" ", maxWidth-utf8.RuneCountInString(name))
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		return padding + name
+	}
+	return name + padding
+}
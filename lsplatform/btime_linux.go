@@ -0,0 +1,92 @@
+//go:build linux
+
+package lsplatform
+
+import (
+	"io/fs"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// statx(2) is not in syscall package, so we call it directly
+const (
+	atFdCwd           = -0x64
+	atSymlinkNoFollow = 0x100
+	statxBtime        = 0x800
+)
+
+// sysStatx is the number of statx system call, or 0 if unknown
+var sysStatx = map[string]uintptr{
+	"386":      383,
+	"amd64":    332,
+	"arm":      397,
+	"arm64":    291,
+	"loong64":  291,
+	"ppc64":    383,
+	"ppc64le":  383,
+	"riscv64":  291,
+	"s390x":    379,
+	"mips64":   5326,
+	"mips64le": 5326,
+}[runtime.GOARCH]
+
+type statxTimestamp struct {
+	Sec  int64
+	Nsec uint32
+	_    int32
+}
+
+// statxT is struct statx, only up to stx_btime, followed by padding
+type statxT struct {
+	Mask           uint32
+	Blksize        uint32
+	Attributes     uint64
+	Nlink          uint32
+	UID            uint32
+	GID            uint32
+	Mode           uint16
+	_              uint16
+	Ino            uint64
+	Size           uint64
+	Blocks         uint64
+	AttributesMask uint64
+	Atime          statxTimestamp
+	Btime          statxTimestamp
+	_              [160]byte
+}
+
+// FileBTime returns birth (creation) time of file, or nil if the
+// kernel or file system does not support it
+func (*LocalPlatform) FileBTime(fileInfo FileInfo) *time.Time {
+	if sysStatx == 0 {
+		return nil
+	}
+	path, err := syscall.BytePtrFromString(fileInfo.PathAbs())
+	if err != nil {
+		return nil
+	}
+	// fileInfo of a dereferenced link (with -L) has the path of link,
+	// but not the symlink mode
+	flags := 0
+	if fileInfo.Mode()&fs.ModeSymlink != 0 {
+		flags = atSymlinkNoFollow
+	}
+	fd := atFdCwd
+	var stat statxT
+	_, _, errno := syscall.Syscall6(
+		sysStatx,
+		uintptr(fd),
+		uintptr(unsafe.Pointer(path)),
+		uintptr(flags),
+		statxBtime,
+		uintptr(unsafe.Pointer(&stat)),
+		0,
+	)
+	if errno != 0 || stat.Mask&statxBtime == 0 {
+		return nil
+	}
+	btime := time.Unix(stat.Btime.Sec, int64(stat.Btime.Nsec))
+	return &btime
+}
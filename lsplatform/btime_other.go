@@ -0,0 +1,10 @@
+//go:build !(linux || freebsd || darwin || netbsd || windows)
+
+package lsplatform
+
+import "time"
+
+// FileBTime returns nil, birth time is not supported on this platform
+func (*LocalPlatform) FileBTime(FileInfo) *time.Time {
+	return nil
+}
@@ -18,3 +18,9 @@ func (*LocalPlatform) FileATime(fileInfo FileInfo) *time.Time {
 	atime := time.Unix(int64(stat.Atimespec.Sec), int64(stat.Atimespec.Nsec))
 	return &atime
 }
+
+func (*LocalPlatform) FileBTime(fileInfo FileInfo) *time.Time {
+	stat := fileInfo.Sys().(*syscall.Stat_t)
+	btime := time.Unix(int64(stat.Birthtimespec.Sec), int64(stat.Birthtimespec.Nsec))
+	return &btime
+}
@@ -0,0 +1,111 @@
+package lsplatform
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MountInfo is one line of /proc/self/mountinfo
+// see proc(5) for details about each field
+type MountInfo struct {
+	ID         int
+	ParentID   int
+	Major      uint32
+	Minor      uint32
+	Root       string
+	MountPoint string
+	Options    string
+	FsType     string
+	Source     string
+}
+
+// unescapeMountPath decodes octal escapes (like \040 for space)
+// used in mount point and root fields
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			code, err := strconv.ParseUint(path[i+1:i+4], 8, 8)
+			if err == nil {
+				sb.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(path[i])
+	}
+	return sb.String()
+}
+
+func parseMountInfoLine(line string) (*MountInfo, error) {
+	// 36 35 98:0 /mnt1 /mnt/parent rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+	fields := strings.Fields(line)
+	sepIndex := -1
+	for i := 6; i < len(fields); i++ {
+		if fields[i] == "-" {
+			sepIndex = i
+			break
+		}
+	}
+	if len(fields) < 7 || sepIndex < 0 || len(fields) < sepIndex+3 {
+		return nil, fmt.Errorf("invalid mountinfo line %#v", line)
+	}
+	id, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount id in mountinfo line %#v", line)
+	}
+	parentID, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent id in mountinfo line %#v", line)
+	}
+	majorStr, minorStr, ok := strings.Cut(fields[2], ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid device number in mountinfo line %#v", line)
+	}
+	major, err := strconv.ParseUint(majorStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid device number in mountinfo line %#v", line)
+	}
+	minor, err := strconv.ParseUint(minorStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid device number in mountinfo line %#v", line)
+	}
+	return &MountInfo{
+		ID:         id,
+		ParentID:   parentID,
+		Major:      uint32(major),
+		Minor:      uint32(minor),
+		Root:       unescapeMountPath(fields[3]),
+		MountPoint: unescapeMountPath(fields[4]),
+		Options:    fields[5],
+		FsType:     fields[sepIndex+1],
+		Source:     unescapeMountPath(fields[sepIndex+2]),
+	}, nil
+}
+
+// ParseMountInfo parses the content of /proc/self/mountinfo
+func ParseMountInfo(reader io.Reader) ([]*MountInfo, error) {
+	mounts := []*MountInfo{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		mount, err := parseMountInfoLine(line)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, mount)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mounts, nil
+}
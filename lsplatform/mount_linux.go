@@ -0,0 +1,15 @@
+//go:build linux
+
+package lsplatform
+
+import "os"
+
+// Mounts returns the list of mounted filesystems visible to this process
+func (*LocalPlatform) Mounts() ([]*MountInfo, error) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseMountInfo(file)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package lsplatform
+
+// Mounts returns the list of mounted filesystems visible to this process
+// only supported on Linux (through /proc/self/mountinfo) for now
+func (*LocalPlatform) Mounts() ([]*MountInfo, error) {
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package lsplatform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	is := is.New(t)
+	input := strings.Join([]string{
+		"23 28 0:22 / /proc rw,relatime - proc proc rw",
+		"36 35 98:0 /mnt1 /mnt/parent rw,noatime master:1 - ext3 /dev/root rw,errors=continue",
+		`40 28 0:45 / /mnt/my\040disk rw,relatime shared:20 - fuseblk /dev/sdb1 rw,user_id=0`,
+		"41 28 0:46 / /merged rw,relatime - overlay overlay rw,lowerdir=/l,upperdir=/u,workdir=/w",
+	}, "\n")
+	mounts, err := ParseMountInfo(strings.NewReader(input))
+	if !is.NotErr(err) {
+		return
+	}
+	is.Equal(len(mounts), 4)
+
+	is.Equal(mounts[0].MountPoint, "/proc")
+	is.Equal(mounts[0].FsType, "proc")
+
+	m := mounts[1]
+	is.Equal(m.ID, 36)
+	is.Equal(m.ParentID, 35)
+	is.Equal(m.Major, uint32(98))
+	is.Equal(m.Minor, uint32(0))
+	is.Equal(m.Root, "/mnt1")
+	is.Equal(m.MountPoint, "/mnt/parent")
+	is.Equal(m.Options, "rw,noatime")
+	is.Equal(m.FsType, "ext3")
+	is.Equal(m.Source, "/dev/root")
+
+	is.Equal(mounts[2].MountPoint, "/mnt/my disk")
+	is.Equal(mounts[2].FsType, "fuseblk")
+	is.Equal(mounts[3].FsType, "overlay")
+
+	_, err = ParseMountInfo(strings.NewReader("1 2 3"))
+	is.Err(err)
+}
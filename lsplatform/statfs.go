@@ -0,0 +1,27 @@
+//go:build linux || darwin || freebsd
+
+package lsplatform
+
+import (
+	"syscall"
+
+	"github.com/ilius/ls-go/common"
+)
+
+// DiskUsage returns free and total space of the filesystem containing path
+func (*LocalPlatform) DiskUsage(path string) (*common.DiskUsage, error) {
+	stat := &syscall.Statfs_t{}
+	err := syscall.Statfs(path, stat)
+	if err != nil {
+		return nil, &PlatformError{
+			Operation: "statfs",
+			Path:      path,
+			Msg:       err.Error(),
+		}
+	}
+	bsize := uint64(stat.Bsize)
+	return &common.DiskUsage{
+		Free:  uint64(stat.Bavail) * bsize,
+		Total: uint64(stat.Blocks) * bsize,
+	}, nil
+}
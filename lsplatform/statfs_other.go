@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd)
+
+package lsplatform
+
+import "github.com/ilius/ls-go/common"
+
+// DiskUsage returns free and total space of the filesystem containing path
+func (*LocalPlatform) DiskUsage(path string) (*common.DiskUsage, error) {
+	return nil, &PlatformError{
+		Operation: "statfs",
+		Path:      path,
+		Msg:       "not supported on this platform",
+	}
+}
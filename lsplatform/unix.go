@@ -114,6 +114,11 @@ func (*LocalPlatform) FileInode(fileInfo FileInfo) (uint64, error) {
 	return fileInfo.Sys().(*syscall.Stat_t).Ino, nil
 }
 
+// FileDevice returns ID of the device containing the file (not the device it represents)
+func (*LocalPlatform) FileDevice(fileInfo FileInfo) (uint64, error) {
+	return uint64(fileInfo.Sys().(*syscall.Stat_t).Dev), nil
+}
+
 // FileBlocks returns number of 1024-byte blocks occupied by a file
 func (*LocalPlatform) FileBlocks(fileInfo FileInfo) int64 {
 	return fileInfo.Sys().(*syscall.Stat_t).Blocks / 2
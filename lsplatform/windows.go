@@ -173,11 +173,11 @@ func (*LocalPlatform) NumberOfHardLinks(info FileInfo) (uint64, error) {
 	return uint64(fi.NumberOfLinks), nil
 }
 
-func (*LocalPlatform) FileInode(info FileInfo) (uint64, error) {
+func fileInformation(info FileInfo) (*syscall.ByHandleFileInformation, error) {
 	path := info.PathAbs()
 	pathPtr, err := syscall.UTF16PtrFromString(path)
 	if err != nil {
-		return 0, &PlatformError{
+		return nil, &PlatformError{
 			Operation: "syscall.UTF16PtrFromString",
 			Path:      path,
 			Msg:       err.Error(),
@@ -197,26 +197,42 @@ func (*LocalPlatform) FileInode(info FileInfo) (uint64, error) {
 		0,                     // templatefile
 	)
 	if err != nil {
-		return 0, &PlatformError{
+		return nil, &PlatformError{
 			Operation: "syscall.CreateFile",
 			Path:      path,
 			Msg:       err.Error(),
 		}
 	}
+	defer syscall.CloseHandle(handle)
 
 	var fi syscall.ByHandleFileInformation
 	if err = syscall.GetFileInformationByHandle(handle, &fi); err != nil {
-		syscall.CloseHandle(handle)
-		return 0, &PlatformError{
+		return nil, &PlatformError{
 			Operation: "syscall.GetFileInformationByHandle",
 			Path:      path,
 			Msg:       err.Error(),
 		}
 	}
+	return &fi, nil
+}
 
+func (*LocalPlatform) FileInode(info FileInfo) (uint64, error) {
+	fi, err := fileInformation(info)
+	if err != nil {
+		return 0, err
+	}
 	return uint64(fi.FileIndexHigh)<<32 | uint64(fi.FileIndexLow), nil
 }
 
+// FileDevice returns serial number of the volume containing the file
+func (*LocalPlatform) FileDevice(info FileInfo) (uint64, error) {
+	fi, err := fileInformation(info)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(fi.VolumeSerialNumber), nil
+}
+
 func (*LocalPlatform) FileCTime(info FileInfo) *time.Time {
 	data := info.Sys().(*syscall.Win32FileAttributeData)
 	_time := time.Unix(0, data.LastWriteTime.Nanoseconds())
@@ -229,6 +245,12 @@ func (*LocalPlatform) FileATime(info FileInfo) *time.Time {
 	return &_time
 }
 
+func (*LocalPlatform) FileBTime(info FileInfo) *time.Time {
+	data := info.Sys().(*syscall.Win32FileAttributeData)
+	_time := time.Unix(0, data.CreationTime.Nanoseconds())
+	return &_time
+}
+
 // FileBlocks returns number of 1024-byte blocks occupied by a file
 func (*LocalPlatform) FileBlocks(_ FileInfo) int64 {
 	// FIXME
@@ -0,0 +1,278 @@
+package lssize
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// unitLetters are letters of units, each is 1024 (or 1000) times the previous
+const unitLetters = "KMGTPEZY"
+
+// Format is how sizes (or blocks, in bytes) are formatted
+type Format struct {
+	// Human is true for human-readable sizes like "1.5K", with powers of
+	// Base (1024, or 1000 for --si)
+	Human bool
+	Base  uint64
+
+	// IEC is true for IEC suffixes in human-readable sizes, like "1.5KiB"
+	IEC bool
+
+	// Decimals is the number of decimals in human-readable sizes, or -1
+	// for one decimal if less than 10 and none otherwise (like coreutils)
+	Decimals int
+
+	// BlockSize is the unit if not human-readable (1 for bytes), and Suffix
+	// is shown after number, like "M" for --block-size=M
+	BlockSize uint64
+	Suffix    string
+
+	// Grouping is true for separating thousands with comma, like "1,234"
+	Grouping bool
+}
+
+// Bytes is the format of sizes in bytes, like "1234"
+func Bytes() *Format {
+	return &Format{BlockSize: 1}
+}
+
+// Human returns format of human-readable sizes with powers of base
+func Human(base uint64) *Format {
+	return &Format{Human: true, Base: base, Decimals: -1}
+}
+
+// Unit returns number of bytes of a unit like "K", "KB" or "KiB"
+// (letter of unit is case-insensitive), returns 0 if invalid
+func Unit(unit string) uint64 {
+	switch unit {
+	case "", "B":
+		return 1
+	}
+	index := strings.IndexByte(unitLetters, strings.ToUpper(unit[:1])[0])
+	if index < 0 {
+		return 0
+	}
+	base := uint64(1024)
+	switch unit[1:] {
+	case "", "iB":
+	case "B":
+		base = 1000
+	default:
+		return 0
+	}
+	result := uint64(1)
+	for i := 0; i <= index; i++ {
+		hi, lo := bits.Mul64(result, base)
+		if hi != 0 {
+			return 0
+		}
+		result = lo
+	}
+	return result
+}
+
+// splitNumber splits str into leading digits and the rest
+func splitNumber(str string) (string, string) {
+	i := 0
+	for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+		i++
+	}
+	return str[:i], str[i:]
+}
+
+// ParseSize parses a size like "10", "10K", "10KB", "10KiB" or "1.5G" to
+// bytes (K is 1024, KB is 1000, KiB is 1024), rounded up
+func ParseSize(str string) (uint64, error) {
+	numStr, unitStr := splitNumber(strings.TrimSpace(str))
+	fracStr := ""
+	if strings.HasPrefix(unitStr, ".") {
+		fracStr, unitStr = splitNumber(unitStr[1:])
+		if fracStr == "" {
+			return 0, fmt.Errorf("invalid size %#v", str)
+		}
+	}
+	if numStr == "" {
+		return 0, fmt.Errorf("invalid size %#v", str)
+	}
+	unit := Unit(unitStr)
+	if unit == 0 {
+		return 0, fmt.Errorf("invalid size %#v: invalid unit %#v", str, unitStr)
+	}
+	num, err := strconv.ParseUint(numStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %#v", str)
+	}
+	hi, size := bits.Mul64(num, unit)
+	if hi != 0 {
+		return 0, fmt.Errorf("size %#v is too large", str)
+	}
+	if fracStr == "" {
+		return size, nil
+	}
+	// ignore digits that can not make a difference
+	fracStr = fracStr[:min(len(fracStr), 18)]
+	frac, err := strconv.ParseUint(fracStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %#v", str)
+	}
+	fracSize, ok := ceilDiv(frac, unit, pow10(len(fracStr)))
+	if !ok {
+		return 0, fmt.Errorf("size %#v is too large", str)
+	}
+	size, carry := bits.Add64(size, fracSize, 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("size %#v is too large", str)
+	}
+	return size, nil
+}
+
+// ParseBlockSize parses value of GNU --block-size like "1K", "M", "KiB",
+// "'1K" (with thousands grouping), "human-readable" or "si"
+// if there is no number (like "M"), the unit is shown after sizes
+func ParseBlockSize(str string) (*Format, error) {
+	switch str {
+	case "human-readable":
+		return Human(1024), nil
+	case "si":
+		return Human(1000), nil
+	}
+	format := &Format{}
+	if strings.HasPrefix(str, "'") {
+		format.Grouping = true
+		str = str[1:]
+	}
+	numStr, unitStr := splitNumber(str)
+	if numStr == "" {
+		unit := Unit(unitStr)
+		if unitStr == "" || unit == 0 {
+			return nil, fmt.Errorf("invalid block size %#v", str)
+		}
+		format.BlockSize = unit
+		format.Suffix = unitStr
+		return format, nil
+	}
+	size, err := ParseSize(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block size %#v", str)
+	}
+	if size == 0 || strings.Contains(str, ".") {
+		return nil, fmt.Errorf("invalid block size %#v", str)
+	}
+	format.BlockSize = size
+	return format, nil
+}
+
+// ceilDiv returns ceil(a * mul / b), and false if it overflows
+func ceilDiv(a uint64, mul uint64, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, mul)
+	lo, carry := bits.Add64(lo, b-1, 0)
+	hi += carry
+	if hi >= b {
+		return 0, false
+	}
+	quo, _ := bits.Div64(hi, lo, b)
+	return quo, true
+}
+
+func pow10(n int) uint64 {
+	result := uint64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+// groupThousands inserts commas between groups of 3 digits
+func groupThousands(str string) string {
+	intPart, fracPart, hasFrac := strings.Cut(str, ".")
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString("." + fracPart)
+	}
+	return b.String()
+}
+
+// formatFixed formats value / 10^decimals, like "1.5" for 15 and 1 decimal
+func formatFixed(value uint64, decimals int) string {
+	str := strconv.FormatUint(value, 10)
+	if decimals == 0 {
+		return str
+	}
+	if len(str) <= decimals {
+		str = strings.Repeat("0", decimals-len(str)+1) + str
+	}
+	return str[:len(str)-decimals] + "." + str[len(str)-decimals:]
+}
+
+// humanString returns number and unit letter (empty for bytes), rounded up
+// like coreutils
+func (f *Format) humanString(size uint64) (string, string) {
+	if size < f.Base {
+		return strconv.FormatUint(size, 10), ""
+	}
+	unit := uint64(1)
+	for index := 0; index < len(unitLetters); index++ {
+		unit *= f.Base
+		decimals := f.Decimals
+		if decimals < 0 {
+			decimals = 0
+			if whole, _ := ceilDiv(size, 10, unit); whole < 100 {
+				// less than 10, after rounding up to one decimal
+				decimals = 1
+			}
+		}
+		value, ok := ceilDiv(size, pow10(decimals), unit)
+		if !ok {
+			break
+		}
+		if value < f.Base*pow10(decimals) || index == len(unitLetters)-1 {
+			return formatFixed(value, decimals), unitLetters[index : index+1]
+		}
+	}
+	return strconv.FormatUint(size, 10), ""
+}
+
+// Format formats size (in bytes), returns formatted size and key of
+// size colors ("B", "K", "M", ... or empty if not human-readable and
+// there is no suffix)
+func (f *Format) Format(size uint64) (string, string) {
+	if !f.Human {
+		value, _ := ceilDiv(size, 1, f.BlockSize)
+		str := strconv.FormatUint(value, 10)
+		if f.Grouping {
+			str = groupThousands(str)
+		}
+		if f.Suffix == "" {
+			return str, ""
+		}
+		return str + f.Suffix, strings.ToUpper(f.Suffix[:1])
+	}
+	str, letter := f.humanString(size)
+	if f.Grouping {
+		str = groupThousands(str)
+	}
+	if letter == "" {
+		return str + "B", "B"
+	}
+	if f.IEC {
+		return str + letter + "iB", letter
+	}
+	return str + letter, letter
+}
+
+// Unit returns size of unit of numbers (if not human-readable), like
+// 1024 for --block-size=K, or 1 for human-readable sizes
+func (f *Format) Unit() uint64 {
+	if f.Human {
+		return 1
+	}
+	return f.BlockSize
+}
@@ -0,0 +1,84 @@
+package lssize
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseSize(t *testing.T) {
+	is := is.New(t)
+	test := func(str string, expected uint64) {
+		size, err := ParseSize(str)
+		is := is.AddMsg("str=%#v", str)
+		is.NotErr(err)
+		is.Equal(size, expected)
+	}
+	test("0", 0)
+	test("10", 10)
+	test("10B", 10)
+	test("10K", 10240)
+	test("10k", 10240)
+	test("10KiB", 10240)
+	test("10KB", 10000)
+	test("10kB", 10000)
+	test("3M", 3*1024*1024)
+	test("2GB", 2000000000)
+	test("1.5K", 1536)
+	test("1.5GiB", 1610612736)
+	test("0.1K", 103)
+	test("2.25MB", 2250000)
+	for _, str := range []string{"", "K", "10X", "10Kb", "99999999999E", "1.K", ".5K", "1.5.K", "16.5E"} {
+		_, err := ParseSize(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
+
+func TestParseBlockSize(t *testing.T) {
+	is := is.New(t)
+	f, err := ParseBlockSize("M")
+	is.NotErr(err)
+	is.Equal(f, &Format{BlockSize: 1048576, Suffix: "M"})
+	f, err = ParseBlockSize("'1K")
+	is.NotErr(err)
+	is.Equal(f, &Format{BlockSize: 1024, Grouping: true})
+	f, err = ParseBlockSize("si")
+	is.NotErr(err)
+	is.Equal(f, Human(1000))
+	for _, str := range []string{"", "0", "'", "X", "1X", "1.5K"} {
+		_, err := ParseBlockSize(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	is := is.New(t)
+	test := func(f *Format, size uint64, expected string, expectedKey string) {
+		str, key := f.Format(size)
+		is := is.AddMsg("format=%+v, size=%d", f, size)
+		is.Equal(str, expected)
+		is.Equal(key, expectedKey)
+	}
+	human := Human(1024)
+	// same as `ls -lh` of coreutils
+	test(human, 0, "0B", "B")
+	test(human, 1023, "1023B", "B")
+	test(human, 1024, "1.0K", "K")
+	test(human, 1025, "1.1K", "K")
+	test(human, 1536, "1.5K", "K")
+	test(human, 10239, "10K", "K")
+	test(human, 10241, "11K", "K")
+	test(human, 1048575, "1.0M", "M")
+	test(human, 2000000, "2.0M", "M")
+	test(human, 1<<62, "4.0E", "E")
+	test(Human(1000), 1000, "1.0K", "K")
+	test(Human(1000), 999999, "1.0M", "M")
+	test(&Format{Human: true, Base: 1024, IEC: true, Decimals: -1}, 1536, "1.5KiB", "K")
+	test(&Format{Human: true, Base: 1024, Decimals: 2}, 2000000, "1.91M", "M")
+	test(&Format{Human: true, Base: 1024, Decimals: 0}, 1536, "2K", "K")
+	test(Bytes(), 1234567, "1234567", "")
+	test(&Format{BlockSize: 1, Grouping: true}, 1234567, "1,234,567", "")
+	test(&Format{BlockSize: 1024}, 1025, "2", "")
+	test(&Format{BlockSize: 1048576, Suffix: "M"}, 2000000, "2M", "M")
+	test(&Format{BlockSize: 1000, Suffix: "kB"}, 2000000, "2000kB", "K")
+}
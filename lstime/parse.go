@@ -0,0 +1,77 @@
+package lstime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ageUnits are units of ParseAge, months and years are approximate
+// (30 and 365 days, like relativeUnits)
+var ageUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  Day,
+	"w":  7 * Day,
+	"mo": 30 * Day,
+	"y":  365 * Day,
+}
+
+// ParseAge parses an age like "2h", "30d", "1w2d" or "1.5h"
+// units: s, m (minutes), h, d, w, mo (30 days) and y (365 days)
+func ParseAge(str string) (time.Duration, error) {
+	rest := strings.TrimSpace(str)
+	if rest == "" {
+		return 0, fmt.Errorf("invalid age %#v", str)
+	}
+	var total time.Duration
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(rest) && rest[j] >= 'a' && rest[j] <= 'z' {
+			j++
+		}
+		num, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %#v", str)
+		}
+		unit, ok := ageUnits[rest[i:j]]
+		if !ok {
+			return 0, fmt.Errorf("invalid age %#v: invalid unit %#v", str, rest[i:j])
+		}
+		total += time.Duration(num * float64(unit))
+		rest = rest[j:]
+	}
+	return total, nil
+}
+
+// dateLayouts are layouts accepted by ParseDate, in local time zone
+// unless there is an offset
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 Z0700",
+}
+
+// ParseDate parses a date like "2026-01-01", "2026-01-01 15:04",
+// or a full time in rfc3339 or full-iso style
+// times without an offset are in local time zone (time.Local, see --tz)
+func ParseDate(str string) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	for _, layout := range dateLayouts {
+		tm, err := time.ParseInLocation(layout, str, time.Local)
+		if err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %#v, expected a date like 2026-01-01 or 2026-01-01 15:04", str)
+}
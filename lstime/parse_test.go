@@ -0,0 +1,53 @@
+package lstime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseAge(t *testing.T) {
+	is := is.New(t)
+	test := func(str string, expected time.Duration) {
+		is := is.AddMsg("str=%#v", str)
+		dur, err := ParseAge(str)
+		is.NotErr(err)
+		is.Equal(dur, expected)
+	}
+	test("2h", 2*time.Hour)
+	test("90m", 90*time.Minute)
+	test("30d", 30*Day)
+	test("1w2d", 9*Day)
+	test("1.5h", 90*time.Minute)
+	test("6mo", 180*Day)
+	test("1y", 365*Day)
+	for _, str := range []string{"", "2", "h", "2x", "2H", "-2h", "1..5h"} {
+		_, err := ParseAge(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	is := is.New(t)
+	local := time.Local
+	defer func() {
+		time.Local = local
+	}()
+	time.Local = time.FixedZone("+02", 2*3600)
+	test := func(str string, expected string) {
+		is := is.AddMsg("str=%#v", str)
+		tm, err := ParseDate(str)
+		is.NotErr(err)
+		is.Equal(tm.Format(time.RFC3339), expected)
+	}
+	test("2026-01-01", "2026-01-01T00:00:00+02:00")
+	test("2026-01-01 15:04", "2026-01-01T15:04:00+02:00")
+	test("2026-01-01T15:04:05", "2026-01-01T15:04:05+02:00")
+	test("2026-01-01T15:04:05Z", "2026-01-01T15:04:05Z")
+	test("2026-01-01 15:04:05 +0330", "2026-01-01T15:04:05+03:30")
+	for _, str := range []string{"", "yesterday", "2026-13-01", "01/02/2026"} {
+		_, err := ParseDate(str)
+		is.AddMsg("str=%#v", str).Err(err)
+	}
+}
@@ -0,0 +1,127 @@
+package lstime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type relativeUnit struct {
+	name string
+	dur  time.Duration
+}
+
+// months and years are approximate (30 and 365 days)
+var relativeUnits = []relativeUnit{
+	{"year", 365 * Day},
+	{"month", 30 * Day},
+	{"week", 7 * Day},
+	{"day", Day},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// FormatRelative formats dur (time minus now) like "3 minutes ago" or
+// "in 5 hours", with at most given number of units, for example
+// "2 weeks, 3 days ago" with 2 units
+func FormatRelative(dur time.Duration, units int) string {
+	past := dur < 0
+	if past {
+		dur = -dur
+	}
+	if dur < time.Second {
+		return "now"
+	}
+	if units < 1 {
+		units = 1
+	}
+	parts := []string{}
+	first := -1
+	for i, unit := range relativeUnits {
+		if first >= 0 && i-first >= units {
+			break
+		}
+		count := dur / unit.dur
+		if count == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		dur -= count * unit.dur
+		part := strconv.FormatInt(int64(count), 10) + " " + unit.name
+		if count > 1 {
+			part += "s"
+		}
+		parts = append(parts, part)
+	}
+	str := strings.Join(parts, ", ")
+	if past {
+		return str + " ago"
+	}
+	return "in " + str
+}
+
+// FormatISODuration formats dur in ISO 8601 duration format, like
+// "P3DT4H5M6S", or "-P3DT4H5M6S" for negative durations (for times in past)
+// years, months and weeks are not used, since they are not exact
+func FormatISODuration(dur time.Duration) string {
+	sign := ""
+	if dur < 0 {
+		sign = "-"
+		dur = -dur
+	}
+	days := dur / Day
+	dur -= days * Day
+	hours := dur / time.Hour
+	dur -= hours * time.Hour
+	minutes := dur / time.Minute
+	dur -= minutes * time.Minute
+	seconds := dur / time.Second
+	str := sign + "P"
+	if days > 0 {
+		str += fmt.Sprintf("%dD", days)
+	}
+	if hours == 0 && minutes == 0 && seconds == 0 {
+		if days == 0 {
+			return "PT0S"
+		}
+		return str
+	}
+	str += "T"
+	if hours > 0 {
+		str += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 {
+		str += fmt.Sprintf("%dM", minutes)
+	}
+	if seconds > 0 {
+		str += fmt.Sprintf("%dS", seconds)
+	}
+	return str
+}
+
+// AgeKey returns key of age for dur (time minus now), used for
+// colors of relative times: "future", "minute" (less than an hour),
+// "hour", "day", "week", "month" or "year"
+func AgeKey(dur time.Duration) string {
+	if dur > time.Second {
+		return "future"
+	}
+	dur = -dur
+	switch {
+	case dur < time.Hour:
+		return "minute"
+	case dur < Day:
+		return "hour"
+	case dur < 7*Day:
+		return "day"
+	case dur < 30*Day:
+		return "week"
+	case dur < 365*Day:
+		return "month"
+	}
+	return "year"
+}
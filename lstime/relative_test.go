@@ -0,0 +1,50 @@
+package lstime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestFormatRelative(t *testing.T) {
+	is := is.New(t)
+	test := func(dur time.Duration, units int, expected string) {
+		is.AddMsg("dur=%v, units=%d", dur, units).Equal(FormatRelative(dur, units), expected)
+	}
+	test(0, 1, "now")
+	test(-3*time.Minute-20*time.Second, 1, "3 minutes ago")
+	test(-time.Minute, 1, "1 minute ago")
+	test(5*time.Hour+time.Minute, 1, "in 5 hours")
+	test(-17*Day, 1, "2 weeks ago")
+	test(-17*Day, 2, "2 weeks, 3 days ago")
+	test(-17*Day-5*time.Hour, 2, "2 weeks, 3 days ago")
+	test(-14*Day-5*time.Hour, 2, "2 weeks ago")
+	test(-14*Day-5*time.Hour, 3, "2 weeks, 5 hours ago")
+	test(-400*Day, 1, "1 year ago")
+	test(-400*Day, 0, "1 year ago")
+}
+
+func TestFormatISODuration(t *testing.T) {
+	is := is.New(t)
+	test := func(dur time.Duration, expected string) {
+		is.AddMsg("dur=%v", dur).Equal(FormatISODuration(dur), expected)
+	}
+	test(0, "PT0S")
+	test(500*time.Millisecond, "PT0S")
+	test(-3*time.Minute-20*time.Second, "-PT3M20S")
+	test(5*time.Hour, "PT5H")
+	test(-17*Day, "-P17D")
+	test(3*Day+4*time.Hour+5*time.Minute+6*time.Second, "P3DT4H5M6S")
+}
+
+func TestAgeKey(t *testing.T) {
+	is := is.New(t)
+	is.Equal(AgeKey(time.Hour), "future")
+	is.Equal(AgeKey(0), "minute")
+	is.Equal(AgeKey(-2*time.Hour), "hour")
+	is.Equal(AgeKey(-2*Day), "day")
+	is.Equal(AgeKey(-8*Day), "week")
+	is.Equal(AgeKey(-31*Day), "month")
+	is.Equal(AgeKey(-366*Day), "year")
+}
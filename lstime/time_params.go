@@ -1,16 +1,47 @@
 package lstime
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ilius/ls-go/lslocale"
+)
+
+// sixMonths is half of an average Gregorian year, like GNU ls
+const sixMonths = 15778476 * time.Second
+
+// IsRecent returns true if tm is in the last six months before now
+// (like GNU ls, times in the future are not recent)
+func IsRecent(tm time.Time, now time.Time) bool {
+	return !tm.After(now) && now.Sub(tm) < sixMonths
+}
 
 // UnixFormatStr will be empty unless set by user with --time-style=+...
+// (or by a GNU style like "locale" or "iso")
 // so if UnixFormatStr is set, we ignore FormatStr
+// if UnixFormatStrOld is also set, UnixFormatStr is used for recent times
+// and UnixFormatStrOld for older (and future) times
+// Locale is used for names of months and days in UnixFormatStr
 // in case of UnixFormatStr, MaxWidth is set once and used afterwards
-// if Relative is true, we ignore all other fields
+// if Relative is true, we ignore all other fields except RelativeUnits
+// (the maximum number of units, like 2 for "2 weeks, 3 days ago")
 type TimeParams struct {
-	FormatStr     string
-	UnixFormatStr string
-	MaxWidth      int
-	Relative      bool
+	FormatStr        string
+	UnixFormatStr    string
+	UnixFormatStrOld string
+	MaxWidth         int
+	Relative         bool
+	RelativeUnits    int
+	Locale           *lslocale.Locale
+}
+
+func (f *TimeParams) setUnixFormats(recent string, old string) {
+	f.UnixFormatStr = recent
+	f.UnixFormatStrOld = old
+	if f.Locale == nil {
+		f.Locale = lslocale.ForTime()
+	}
 }
 
 func (f *TimeParams) SetTimeStyle(style string) error {
@@ -21,23 +52,74 @@ func (f *TimeParams) SetTimeStyle(style string) error {
 	case "full-iso", "full":
 		f.FormatStr = "2006-01-02 15:04:05.999999999 Z0700"
 		return nil
+	case "rfc3339":
+		f.FormatStr = time.RFC3339
+		return nil
+	case "rfc3339-nano":
+		f.FormatStr = time.RFC3339Nano
+		return nil
 	case "long-iso", "long":
 		f.FormatStr = "2006-01-02 15:04"
 		return nil
 	case "iso":
-		f.FormatStr = "01-02 15:04"
+		f.setUnixFormats("%m-%d %H:%M", "%Y-%m-%d ")
 		return nil
 	case "locale":
-		// use https://github.com/Xuanwo/go-locale
-		return fmt.Errorf("unsupported time style 'locale'")
+		f.Locale = lslocale.ForTime()
+		f.setUnixFormats(f.Locale.RecentFormat, f.Locale.OldFormat)
+		return nil
 	case "relative", "rel":
 		f.Relative = true
 		return nil
 	}
+	if strings.HasPrefix(style, "posix-") {
+		// like GNU ls, "posix-STYLE" means STYLE, unless in POSIX locale
+		if lslocale.IsPOSIX(lslocale.Env("LC_TIME")) {
+			return f.SetTimeStyle("locale")
+		}
+		return f.SetTimeStyle(style[len("posix-"):])
+	}
 	// now it must start with + following time format
 	if style[0] != '+' {
 		return fmt.Errorf("invalid time style %#v", style)
 	}
-	f.UnixFormatStr = style[1:]
+	// "+FORMAT1\nFORMAT2" means FORMAT1 for old and FORMAT2 for recent times
+	old, recent, ok := strings.Cut(style[1:], "\n")
+	if !ok {
+		f.setUnixFormats(old, "")
+		return nil
+	}
+	if strings.Contains(recent, "\n") {
+		return fmt.Errorf("invalid time style %#v: more than one newline", style)
+	}
+	f.setUnixFormats(recent, old)
 	return nil
 }
+
+// FormatTime formats tm, now is used to check if tm is recent, or for
+// relative time
+func (f *TimeParams) FormatTime(tm time.Time, now time.Time) string {
+	if f.Relative {
+		return FormatRelative(tm.Sub(now), f.RelativeUnits)
+	}
+	// time.Local is set by --tz, and tm may be from another zone (like
+	// with --read-json)
+	tm = tm.In(time.Local)
+	if f.UnixFormatStr == "" && f.UnixFormatStrOld == "" {
+		return tm.Format(f.FormatStr)
+	}
+	format := f.UnixFormatStr
+	if f.UnixFormatStrOld != "" && !IsRecent(tm, now) {
+		format = f.UnixFormatStrOld
+	}
+	return f.Locale.Strftime(tm, format)
+}
+
+// FormatData is like FormatTime, but for machine-readable output (json and
+// csv), where relative time is formatted as ISO 8601 duration
+func (f *TimeParams) FormatData(tm time.Time, now time.Time) string {
+	if f.Relative {
+		return FormatISODuration(tm.Sub(now))
+	}
+	return f.FormatTime(tm, now)
+}
@@ -0,0 +1,68 @@
+package lstime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestTimeParams_FormatTime(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	local := time.Local
+	time.Local = time.UTC
+	defer func() {
+		time.Local = local
+	}()
+	is := is.New(t)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	recent := time.Date(2026, 5, 5, 9, 7, 0, 0, time.UTC)
+	old := time.Date(2020, 3, 5, 9, 7, 0, 0, time.UTC)
+	future := time.Date(2026, 10, 20, 9, 7, 0, 0, time.UTC)
+	test := func(style string, tm time.Time, expected string) {
+		f := &TimeParams{}
+		is := is.AddMsg("style=%#v, tm=%v", style, tm)
+		is.NotErr(f.SetTimeStyle(style))
+		is.Equal(f.FormatTime(tm, now), expected)
+	}
+	test("locale", recent, "May  5 09:07")
+	test("locale", old, "Mar  5  2020")
+	test("locale", future, "Oct 20  2026")
+	test("iso", recent, "05-05 09:07")
+	test("iso", old, "2020-03-05 ")
+	test("long-iso", old, "2020-03-05 09:07")
+	test("posix-long-iso", old, "Mar  5  2020")
+	test("rfc3339", old, "2020-03-05T09:07:00Z")
+	test("+%Y/%m/%d", recent, "2026/05/05")
+	test("+%Y-%m-%d\n%b %d %H:%M", recent, "May 05 09:07")
+	test("+%Y-%m-%d\n%b %d %H:%M", old, "2020-03-05")
+
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	test("locale", recent, " 5. Mai 09:07")
+	test("posix-long-iso", old, "2020-03-05 09:07")
+	test("+%a %b", old, "Do Mär")
+
+	f := &TimeParams{}
+	is.Err(f.SetTimeStyle("+a\nb\nc"))
+	is.Err(f.SetTimeStyle("foo"))
+}
+
+func TestLoadLocation(t *testing.T) {
+	is := is.New(t)
+	tm := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	test := func(name string, expected string) {
+		loc, err := LoadLocation(name)
+		is := is.AddMsg("name=%#v", name)
+		is.NotErr(err)
+		is.Equal(tm.In(loc).Format(time.RFC3339), expected)
+	}
+	test("UTC", "2026-10-19T12:00:00Z")
+	test("utc", "2026-10-19T12:00:00Z")
+	test("+03:30", "2026-10-19T15:30:00+03:30")
+	test("-0500", "2026-10-19T07:00:00-05:00")
+	test("UTC+2", "2026-10-19T14:00:00+02:00")
+	for _, name := range []string{"+25", "+01:75", "Nowhere/City"} {
+		_, err := LoadLocation(name)
+		is.AddMsg("name=%#v", name).Err(err)
+	}
+}
@@ -0,0 +1,45 @@
+package lstime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var fixedZoneRE = regexp.MustCompile(`^(?:UTC)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// LoadLocation returns time zone for --tz, which can be:
+// "local" (or empty) for system time zone (or TZ environment variable),
+// "UTC", a fixed offset like "+03:30" or "UTC-5" (which do not need the
+// time zone database), or a name like "Europe/Berlin"
+func LoadLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z":
+		return time.UTC, nil
+	}
+	groups := fixedZoneRE.FindStringSubmatch(name)
+	if groups == nil {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %#v: %w", name, err)
+		}
+		return loc, nil
+	}
+	hours, _ := strconv.Atoi(groups[2])
+	minutes := 0
+	if groups[3] != "" {
+		minutes, _ = strconv.Atoi(groups[3])
+	}
+	if hours > 14 || minutes > 59 {
+		return nil, fmt.Errorf("invalid time zone offset %#v", name)
+	}
+	offset := hours*3600 + minutes*60
+	if groups[1] == "-" {
+		offset = -offset
+	}
+	return time.FixedZone(name, offset), nil
+}
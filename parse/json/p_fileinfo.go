@@ -13,6 +13,19 @@ import (
 
 const timeFmt = "2006-01-02 15:04:05.999999999 Z0700"
 
+// parseTime parses time in full-iso or rfc3339 time style
+func parseTime(str string) (time.Time, error) {
+	tm, err := time.Parse(timeFmt, str)
+	if err == nil {
+		return tm, nil
+	}
+	tm, err2 := time.Parse(time.RFC3339Nano, str)
+	if err2 == nil {
+		return tm, nil
+	}
+	return tm, err
+}
+
 var (
 	platform = lsplatform.New()
 	fspaths  = &paths.LocalFilePath{}
@@ -62,6 +75,8 @@ type FakeFileInfo struct {
 	F_hardLinks uint64 `json:"hard_links"`
 	F_blocks    int64  `json:"blocks"`
 
+	F_devInode [2]uint64 `json:"dev_inode"`
+
 	F_deviceNumbers string // `json:""`
 }
 
@@ -108,21 +123,21 @@ func (fi *FakeFileInfo) Prepare() error {
 	fi.pathAbs = pathAbs
 	fi.pathDisplay = name
 	if fi.S_mtime != "" {
-		_time, err := time.Parse(timeFmt, fi.S_mtime)
+		_time, err := parseTime(fi.S_mtime)
 		if err != nil {
 			return err
 		}
 		fi.mtime = &_time
 	}
 	if fi.S_ctime != "" {
-		_time, err := time.Parse(timeFmt, fi.S_ctime)
+		_time, err := parseTime(fi.S_ctime)
 		if err != nil {
 			return err
 		}
 		fi.ctime = &_time
 	}
 	if fi.S_atime != "" {
-		_time, err := time.Parse(timeFmt, fi.S_atime)
+		_time, err := parseTime(fi.S_atime)
 		if err != nil {
 			return err
 		}
@@ -194,6 +209,11 @@ func (fi *FakeFileInfo) PathDisplay() string {
 	return fi.pathDisplay
 }
 
+// Depth returns 0, depth is not known with --read-json
+func (fi *FakeFileInfo) Depth() int {
+	return 0
+}
+
 func (fi *FakeFileInfo) Time(colName string) *time.Time {
 	switch colName {
 	case "mtime":
@@ -215,9 +235,16 @@ func (fi *FakeFileInfo) Group() string {
 }
 
 func (fi *FakeFileInfo) Inode() (uint64, error) {
+	if fi.F_inode == 0 {
+		return fi.F_devInode[1], nil
+	}
 	return fi.F_inode, nil
 }
 
+func (fi *FakeFileInfo) DeviceID() (uint64, error) {
+	return fi.F_devInode[0], nil
+}
+
 func (fi *FakeFileInfo) NumberOfHardLinks() (uint64, error) {
 	return fi.F_hardLinks, nil
 }
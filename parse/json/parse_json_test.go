@@ -31,3 +31,13 @@ func TestParseFileInfo(t *testing.T) {
 
 	// is.Equal("", info.)
 }
+
+func TestParseFileInfoRFC3339(t *testing.T) {
+	is := is.New(t)
+	jstr := `{"mode":"-rw-r--r--","size":8451,"mtime":"2022-11-29T10:49:32.639995101+03:30","name":"README.md"}`
+	info, err := ParseFileInfo([]byte(jstr))
+	if !is.NotErr(err) {
+		return
+	}
+	is.Equal("2022-11-29 10:49:32.639995101 +0330", info.ModTime().Format(timeFmt))
+}
@@ -17,4 +17,7 @@ var Titles = [][2]string{
 	{C_ModeOct, "Oct"},
 	{C_HardLinks, "Hard Links"},
 	{C_Blocks, "Blocks"},
+	{C_FsType, "Filesystem"},
+	{C_LinkGroup, "Link Group"},
+	{C_GroupKey, "Group Key"},
 }
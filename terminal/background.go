@@ -0,0 +1,110 @@
+package terminal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backgroundQueryTimeout is the maximum time to wait for terminal to respond
+// to background color query
+const backgroundQueryTimeout = 100 * time.Millisecond
+
+// LightBackground returns true if terminal background is light, for given
+// --background value
+// with "auto" (or empty), it is detected from COLORFGBG, and with "query",
+// also by querying the terminal (if query is true) if COLORFGBG is not set
+// otherwise dark background is assumed
+// querying is opt-in, because a late response (over ssh for example) is
+// left in input of shell
+func (*LocalTerminal) LightBackground(backgroundFlag string, query bool) (bool, error) {
+	switch backgroundFlag {
+	case "light":
+		return true, nil
+	case "dark":
+		return false, nil
+	case "auto", "", "query":
+	default:
+		return false, fmt.Errorf("invalid --background=%s", backgroundFlag)
+	}
+	if light, ok := parseColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return light, nil
+	}
+	if backgroundFlag != "query" || !query {
+		return false, nil
+	}
+	response, err := queryTerminal(oscBackgroundQuery, backgroundQueryTimeout)
+	if err != nil {
+		return false, nil
+	}
+	light, ok := parseOSC11(response)
+	if !ok {
+		return false, nil
+	}
+	return light, nil
+}
+
+// parseColorFgBg parses COLORFGBG (set by rxvt, konsole and some others)
+// which is like "15;0" or "15;default;0", the last one is background color
+func parseColorFgBg(value string) (light bool, ok bool) {
+	if value == "" {
+		return false, false
+	}
+	parts := strings.Split(value, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	return bg == 7 || bg >= 9, true
+}
+
+// OSC 11 asks for background color, and DA1 (primary device attributes)
+// is answered by almost all terminals, so we know when to stop waiting
+// if OSC 11 is not supported
+const oscBackgroundQuery = "\x1b]11;?\x1b\\" + "\x1b[c"
+
+// isQueryResponseComplete returns true if response to oscBackgroundQuery
+// is complete (contains DA1 response)
+func isQueryResponseComplete(response []byte) bool {
+	i := bytes.Index(response, []byte("\x1b[?"))
+	return i >= 0 && bytes.IndexByte(response[i:], 'c') > 0
+}
+
+var errNoTerminal = errors.New("terminal query is not supported")
+
+// parseOSC11 parses response of OSC 11 query, like
+// "\x1b]11;rgb:ffff/ffff/ffff\x1b\\", and returns true if color is light
+func parseOSC11(response []byte) (light bool, ok bool) {
+	const prefix = "\x1b]11;rgb:"
+	i := bytes.Index(response, []byte(prefix))
+	if i < 0 {
+		return false, false
+	}
+	rest := string(response[i+len(prefix):])
+	end := strings.IndexAny(rest, "\x1b\x07")
+	if end < 0 {
+		return false, false
+	}
+	parts := strings.Split(rest[:end], "/")
+	if len(parts) != 3 {
+		return false, false
+	}
+	rgb := [3]float64{}
+	for j, part := range parts {
+		if len(part) < 1 || len(part) > 4 {
+			return false, false
+		}
+		n, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return false, false
+		}
+		rgb[j] = float64(n) / float64(uint64(1)<<(4*len(part))-1)
+	}
+	// relative luminance
+	luminance := 0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2]
+	return luminance > 0.5, true
+}
@@ -0,0 +1,43 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseColorFgBg(t *testing.T) {
+	is := is.New(t)
+	test := func(value string, light bool, ok bool) {
+		actualLight, actualOk := parseColorFgBg(value)
+		is.AddMsg("value=%#v", value).Equal(actualOk, ok)
+		is.AddMsg("value=%#v", value).Equal(actualLight, light)
+	}
+	test("", false, false)
+	test("15;0", false, true)
+	test("0;15", true, true)
+	test("0;7", true, true)
+	test("7;8", false, true)
+	test("15;default;0", false, true)
+	test("0;default", false, false)
+}
+
+func TestParseOSC11(t *testing.T) {
+	is := is.New(t)
+	test := func(response string, light bool, ok bool) {
+		actualLight, actualOk := parseOSC11([]byte(response))
+		is.AddMsg("response=%#v", response).Equal(actualOk, ok)
+		is.AddMsg("response=%#v", response).Equal(actualLight, light)
+	}
+	test("\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62;22c", true, true)
+	test("\x1b]11;rgb:0000/0000/0000\x07", false, true)
+	test("\x1b]11;rgb:fd/f6/e3\x1b\\", true, true)
+	test("\x1b]11;rgb:00/2b/36\x1b\\", false, true)
+	test("\x1b[?62;22c", false, false)
+	test("\x1b]11;rgb:ffff/ffff\x1b\\", false, false)
+	test("\x1b]11;rgb:ffff/ffff/ffff", false, false)
+
+	is.True(isQueryResponseComplete([]byte("\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62;22c")))
+	is.True(isQueryResponseComplete([]byte("\x1b[?1;2c")))
+	is.False(isQueryResponseComplete([]byte("\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62;2")))
+}
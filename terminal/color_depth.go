@@ -0,0 +1,50 @@
+package terminal
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ilius/ls-go/lscolors"
+)
+
+// ColorDepth returns color depth of terminal for given --color-depth value
+// with "auto" (or empty), it is detected from COLORTERM, terminfo and TERM
+func (*LocalTerminal) ColorDepth(depthFlag string) (lscolors.ColorDepth, error) {
+	if depthFlag != "" && depthFlag != "auto" {
+		return lscolors.ParseColorDepth(depthFlag)
+	}
+	return detectColorDepth(os.Getenv("COLORTERM"), os.Getenv("TERM"), terminfoColors), nil
+}
+
+func detectColorDepth(colorTerm string, term string, terminfoColors func(string) int) lscolors.ColorDepth {
+	switch colorTerm {
+	case "truecolor", "24bit":
+		return lscolors.DepthTrue
+	}
+	if term == "" {
+		// not a unix terminal (like Windows console), keep the default
+		return lscolors.Depth256
+	}
+	if strings.HasSuffix(term, "-direct") {
+		return lscolors.DepthTrue
+	}
+	if isLimitedTerm(term) {
+		return lscolors.Depth16
+	}
+	// terminfo is only used to detect truecolor, because many terminals
+	// support 256 colors while their terminfo says 8 (like xterm)
+	if terminfoColors(term) >= 1<<24 {
+		return lscolors.DepthTrue
+	}
+	return lscolors.Depth256
+}
+
+// isLimitedTerm returns true for terminals that are known to support
+// only 16 colors (or less)
+func isLimitedTerm(term string) bool {
+	switch term {
+	case "linux", "ansi", "cons25":
+		return true
+	}
+	return strings.HasPrefix(term, "vt")
+}
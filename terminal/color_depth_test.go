@@ -0,0 +1,82 @@
+package terminal
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ilius/is/v2"
+	"github.com/ilius/ls-go/lscolors"
+)
+
+func TestDetectColorDepth(t *testing.T) {
+	is := is.New(t)
+	terminfo := map[string]int{
+		"xterm":          8,
+		"xterm-256color": 256,
+		"xterm-direct":   1 << 24,
+		"kitty":          256,
+	}
+	terminfoColors := func(term string) int {
+		colors, ok := terminfo[term]
+		if !ok {
+			return -1
+		}
+		return colors
+	}
+	test := func(colorTerm string, term string, expected lscolors.ColorDepth) {
+		actual := detectColorDepth(colorTerm, term, terminfoColors)
+		is.AddMsg("COLORTERM=%#v, TERM=%#v", colorTerm, term).Equal(actual, expected)
+	}
+	test("truecolor", "xterm", lscolors.DepthTrue)
+	test("24bit", "", lscolors.DepthTrue)
+	test("", "", lscolors.Depth256)
+	test("", "xterm", lscolors.Depth256)
+	test("", "xterm-256color", lscolors.Depth256)
+	test("", "xterm-direct", lscolors.DepthTrue)
+	test("", "foot-direct", lscolors.DepthTrue)
+	test("", "screen-256color", lscolors.Depth256)
+	test("", "kitty", lscolors.Depth256)
+	test("", "linux", lscolors.Depth16)
+	test("", "vt220", lscolors.Depth16)
+	test("", "cons25", lscolors.Depth16)
+	test("", "unknown", lscolors.Depth256)
+}
+
+func TestParseTerminfoColors(t *testing.T) {
+	is := is.New(t)
+	makeTerminfo := func(magic int, numSize int, colors int) []byte {
+		names := []byte("test|test terminal\x00") // 19 bytes
+		bools := []byte{1, 0}
+		numCount := 15
+		data := []byte{}
+		for _, n := range []int{magic, len(names), len(bools), numCount, 0, 0} {
+			data = binary.LittleEndian.AppendUint16(data, uint16(n))
+		}
+		data = append(data, names...)
+		data = append(data, bools...)
+		data = append(data, 0) // padding to even offset
+		for i := 0; i < numCount; i++ {
+			n := -1
+			if i == terminfoMaxColor {
+				n = colors
+			}
+			if numSize == 4 {
+				data = binary.LittleEndian.AppendUint32(data, uint32(int32(n)))
+			} else {
+				data = binary.LittleEndian.AppendUint16(data, uint16(int16(n)))
+			}
+		}
+		return data
+	}
+	colors, err := parseTerminfoColors(makeTerminfo(terminfoMagic, 2, 256))
+	is.NotErr(err)
+	is.Equal(colors, 256)
+	colors, err = parseTerminfoColors(makeTerminfo(terminfoMagic32, 4, 1<<24))
+	is.NotErr(err)
+	is.Equal(colors, 1<<24)
+	colors, err = parseTerminfoColors(makeTerminfo(terminfoMagic, 2, -1))
+	is.NotErr(err)
+	is.Equal(colors, -1)
+	_, err = parseTerminfoColors([]byte("not terminfo"))
+	is.ErrMsg(err, "bad terminfo magic number")
+}
@@ -33,7 +33,8 @@ func (fe *LocalTerminal) ColorsEnabled(colorFlag string) (bool, error) {
 	case "never", "n", "no":
 		return false, nil
 	case "auto":
-		return fe.OutputIsTerminal(os.Stdout), nil
+		// dumb terminal does not support colors
+		return fe.OutputIsTerminal(os.Stdout) && os.Getenv("TERM") != "dumb", nil
 	}
 	return false, fmt.Errorf("invalid --color=%s", colorFlag)
 }
@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd)
+
+package terminal
+
+import "time"
+
+func queryTerminal(_ string, _ time.Duration) ([]byte, error) {
+	return nil, errNoTerminal
+}
@@ -0,0 +1,78 @@
+//go:build linux || darwin || freebsd
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+func ioctlTermios(fd uintptr, req uintptr, termios *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		fd,
+		req,
+		uintptr(unsafe.Pointer(termios)),
+	)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// queryTerminal writes query to terminal (/dev/tty) in non-canonical mode
+// without echo, and reads the response until it is complete or timeout
+func queryTerminal(query string, timeout time.Duration) ([]byte, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, errNoTerminal
+	}
+	defer tty.Close()
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var oldState syscall.Termios
+	var ctrlErr error
+	err = conn.Control(func(fd uintptr) {
+		ctrlErr = ioctlTermios(fd, ioctlGetTermios, &oldState)
+		if ctrlErr != nil {
+			return
+		}
+		newState := oldState
+		newState.Lflag &^= syscall.ICANON | syscall.ECHO
+		ctrlErr = ioctlTermios(fd, ioctlSetTermios, &newState)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ctrlErr != nil {
+		return nil, ctrlErr
+	}
+	defer func() {
+		_ = conn.Control(func(fd uintptr) {
+			_ = ioctlTermios(fd, ioctlSetTermios, &oldState)
+		})
+	}()
+	_, err = tty.WriteString(query)
+	if err != nil {
+		return nil, err
+	}
+	err = tty.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		// not pollable, we can not read with timeout
+		return nil, err
+	}
+	response := []byte{}
+	buf := make([]byte, 256)
+	for !isQueryResponseComplete(response) {
+		n, err := tty.Read(buf)
+		response = append(response, buf[:n]...)
+		if err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,115 @@
+package terminal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	terminfoMagic    = 0o432  // legacy format, 16-bit numbers
+	terminfoMagic32  = 0o1036 // extended number format, 32-bit numbers
+	terminfoMaxColor = 13     // index of "colors" (max_colors) number capability
+)
+
+// terminfoDirs returns directories to look for terminfo files, in order
+func terminfoDirs() []string {
+	dirs := []string{}
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	defaults := []string{
+		"/etc/terminfo",
+		"/lib/terminfo",
+		"/usr/share/terminfo",
+		"/usr/lib/terminfo",
+	}
+	if dirsEnv := os.Getenv("TERMINFO_DIRS"); dirsEnv != "" {
+		for _, dir := range strings.Split(dirsEnv, ":") {
+			if dir == "" {
+				dirs = append(dirs, defaults...)
+				continue
+			}
+			dirs = append(dirs, dir)
+		}
+		return dirs
+	}
+	return append(dirs, defaults...)
+}
+
+// findTerminfo returns path of compiled terminfo file for given terminal name
+func findTerminfo(term string) string {
+	if term == "" || strings.ContainsAny(term, "/\\") {
+		return ""
+	}
+	for _, dir := range terminfoDirs() {
+		// on macOS, sub-directory name is the hex code of first letter
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			path := filepath.Join(dir, sub, term)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// parseTerminfoColors returns the "colors" number capability of a compiled
+// terminfo file, or -1 if it is not set
+func parseTerminfoColors(data []byte) (int, error) {
+	if len(data) < 12 {
+		return 0, errors.New("terminfo file is too short")
+	}
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[2*i:]))
+	}
+	numSize := 2
+	switch header[0] {
+	case terminfoMagic:
+	case terminfoMagic32:
+		numSize = 4
+	default:
+		return 0, errors.New("bad terminfo magic number")
+	}
+	namesSize, boolCount, numCount := header[1], header[2], header[3]
+	if numCount <= terminfoMaxColor {
+		return -1, nil
+	}
+	offset := 12 + namesSize + boolCount
+	if offset%2 != 0 {
+		offset++
+	}
+	offset += terminfoMaxColor * numSize
+	if offset+numSize > len(data) {
+		return 0, errors.New("terminfo file is too short")
+	}
+	if numSize == 4 {
+		return int(int32(binary.LittleEndian.Uint32(data[offset:]))), nil
+	}
+	return int(int16(binary.LittleEndian.Uint16(data[offset:]))), nil
+}
+
+// terminfoColors returns number of colors of given terminal from terminfo
+// database, or -1 if not found
+func terminfoColors(term string) int {
+	path := findTerminfo(term)
+	if path == "" {
+		return -1
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1
+	}
+	colors, err := parseTerminfoColors(data)
+	if err != nil {
+		return -1
+	}
+	return colors
+}